@@ -4,25 +4,178 @@ package asmprinter
 
 import (
 	"fmt"
+	"io"
+	"sort"
+	"strings"
 
 	"github.com/kn100/cybemu/instruction"
+	"github.com/kn100/cybemu/loader"
+	"github.com/kn100/cybemu/opcode"
+	"github.com/kn100/cybemu/regions"
 )
 
 // PrintAssy prints a slice of instructions as standard assembly notation.
 func PrintAssy(instructions []instruction.Inst) {
 	for _, inst := range instructions {
-		if inst.TotalBytes == 2 {
-			fmt.Printf("%05x: %02x%02x                      %s\n", inst.Pos, inst.Bytes[0], inst.Bytes[1], inst.String())
-		} else if inst.TotalBytes == 4 {
-			fmt.Printf("%05x: %02x%02x %02x%02x                 %s\n", inst.Pos, inst.Bytes[0], inst.Bytes[1], inst.Bytes[2], inst.Bytes[3], inst.String())
-		} else if inst.TotalBytes == 6 {
-			fmt.Printf("%05x: %02x%02x %02x%02x %02x%02x            %s\n", inst.Pos, inst.Bytes[0], inst.Bytes[1], inst.Bytes[2], inst.Bytes[3], inst.Bytes[4], inst.Bytes[5], inst.String())
-		} else if inst.TotalBytes == 8 {
-			fmt.Printf("%05x: %02x%02x %02x%02x %02x%02x %02x%02x       %s\n", inst.Pos, inst.Bytes[0], inst.Bytes[1], inst.Bytes[2], inst.Bytes[3], inst.Bytes[4], inst.Bytes[5], inst.Bytes[6], inst.Bytes[7], inst.String())
-		} else if inst.TotalBytes == 10 {
-			fmt.Printf("%05x: %02x%02x %02x%02x %02x%02x %02x%02x %02x%02x  %s\n", inst.Pos, inst.Bytes[0], inst.Bytes[1], inst.Bytes[2], inst.Bytes[3], inst.Bytes[4], inst.Bytes[5], inst.Bytes[6], inst.Bytes[7], inst.Bytes[8], inst.Bytes[9], inst.String())
-		} else {
-			panic("Something really strange happened and we ended up with an instruction with more bytes than 10.")
+		fmt.Println(FormatInst(inst))
+	}
+}
+
+// FprintAnnotated writes instructions to w as PrintAssy prints them, with
+// each line preceded by a prefix and, if it isn't empty, followed by a comment,
+// both returned by annotate.
+func FprintAnnotated(w io.Writer, instructions []instruction.Inst, annotate func(instruction.Inst) (prefix string, comment string)) error {
+	for _, inst := range instructions {
+		prefix, comment := annotate(inst)
+		line := prefix + FormatInst(inst)
+		if comment != "" {
+			line += " ; " + comment
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FormatInst returns the line PrintAssy prints for a single instruction: its
+// address, its raw bytes and its assembly notation. Invalid instructions are
+// printed as data, commented as invalid.
+func FormatInst(inst instruction.Inst) string {
+	text := inst.String()
+	if inst.Opcode == opcode.Invalid {
+		text += " ; invalid"
+	}
+	return formatLine(uint32(inst.Pos), inst.Bytes, text)
+}
+
+// formatLine lays out a line as FormatInst does, with up to the first 10 of
+// bytes shown.
+func formatLine(pos uint32, bytes []byte, text string) string {
+	var hex strings.Builder
+	for n := 0; n < len(bytes) && n < 10; n++ {
+		if n > 0 && n%2 == 0 {
+			hex.WriteByte(' ')
+		}
+		fmt.Fprintf(&hex, "%02x", bytes[n])
+	}
+	return fmt.Sprintf("%05x: %-26s%s", pos, hex.String(), text)
+}
+
+// FormatRegion returns the lines PrintRegion prints for a data region: a
+// .vector per vector table entry, a .ascii per string, including any nulls
+// padding it, and a .long per
+// pointer. The addresses held in vectors and pointers are given by name.
+// Code regions have no lines, as they are disassembled instead.
+func FormatRegion(r regions.Region, name func(addr uint32) string) []string {
+	var lines []string
+	switch r.Kind {
+	case regions.Vectors, regions.Pointers:
+		for n := 0; n+4 <= len(r.Data); n += 4 {
+			b := r.Data[n : n+4]
+			addr := uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+			pos := r.Addr + uint32(n)
+			if r.Kind == regions.Pointers {
+				lines = append(lines, formatLine(pos, b, ".long "+name(addr)))
+				continue
+			}
+			line := formatLine(pos, b, ".vector "+name(addr))
+			v := loader.Vector(pos / loader.VectorSize)
+			for _, named := range loader.Vectors {
+				if v == named {
+					line += " ; " + v.String()
+					break
+				}
+			}
+			lines = append(lines, line)
+		}
+	case regions.ASCII:
+		// Nulls padding a string are kept on its line rather than given
+		// lines of their own.
+		start := 0
+		for n := 0; n < len(r.Data); n++ {
+			if r.Data[n] != 0 {
+				continue
+			}
+			for n+1 < len(r.Data) && r.Data[n+1] == 0 {
+				n++
+			}
+			lines = append(lines, formatLine(r.Addr+uint32(start), r.Data[start:n+1], ".ascii "+quote(r.Data[start:n+1])))
+			start = n + 1
+		}
+	}
+	return lines
+}
+
+// PrintRegion prints a data region as FormatRegion formats it.
+func PrintRegion(r regions.Region, name func(addr uint32) string) {
+	for _, line := range FormatRegion(r, name) {
+		fmt.Println(line)
+	}
+}
+
+// quote returns s as a string literal for .ascii.
+func quote(s []byte) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, c := range s {
+		switch c {
+		case 0:
+			b.WriteString(`\0`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// FprintInvalid writes a report of the invalid instructions among
+// instructions to w: how many there are, then for each distinct encoding, its
+// bits, how many times it was found and where.
+func FprintInvalid(w io.Writer, instructions []instruction.Inst) error {
+	found := map[string][]int{}
+	total := 0
+	for _, inst := range instructions {
+		if inst.Opcode == opcode.Invalid {
+			found[string(inst.Bytes)] = append(found[string(inst.Bytes)], inst.Pos)
+			total++
+		}
+	}
+	encodings := make([]string, 0, len(found))
+	for e := range found {
+		encodings = append(encodings, e)
+	}
+	sort.Strings(encodings)
+
+	if _, err := fmt.Fprintf(w, "%d invalid, %d distinct encodings\n", total, len(encodings)); err != nil {
+		return err
+	}
+	for _, e := range encodings {
+		var bits strings.Builder
+		for n, b := range []byte(e) {
+			if n > 0 {
+				bits.WriteByte(' ')
+			}
+			fmt.Fprintf(&bits, "%04b %04b", b>>4, b&0xF)
+		}
+		at := make([]string, len(found[e]))
+		for n, pos := range found[e] {
+			at[n] = fmt.Sprintf("%05x", pos)
+		}
+		inst := instruction.Inst{Opcode: opcode.Invalid, Bytes: []byte(e)}
+		if _, err := fmt.Fprintf(w, "%s  %s  %d at %s\n", inst.String(), bits.String(), len(at), strings.Join(at, ", ")); err != nil {
+			return err
 		}
 	}
+	return nil
 }
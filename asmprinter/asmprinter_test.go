@@ -0,0 +1,60 @@
+package asmprinter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kn100/cybemu/asmprinter"
+	"github.com/kn100/cybemu/disassembler"
+	"github.com/kn100/cybemu/regions"
+	"github.com/stretchr/testify/assert"
+)
+
+func name(addr uint32) string {
+	return "sym"
+}
+
+func TestFormatRegion(t *testing.T) {
+	assert.Equal(t, []string{
+		`00010: 4122 0a00 00              .ascii "A\"\n\0\0"`,
+		`00015: 4f4b 2100                 .ascii "OK!\0"`,
+	}, asmprinter.FormatRegion(regions.Region{Addr: 0x10, Data: []byte{'A', '"', '\n', 0, 0, 'O', 'K', '!', 0}, Kind: regions.ASCII}, name))
+
+	assert.Equal(t, []string{
+		"00020: 0000 0100                 .long sym",
+		"00024: 0000 0200                 .long sym",
+	}, asmprinter.FormatRegion(regions.Region{Addr: 0x20, Data: []byte{0, 0, 1, 0, 0, 0, 2, 0}, Kind: regions.Pointers}, name))
+
+	assert.Equal(t, []string{
+		"00000: 0000 0000                 .vector sym ; reset",
+		"00004: 0000 0000                 .vector sym",
+	}, asmprinter.FormatRegion(regions.Region{Addr: 0, Data: make([]byte, 8), Kind: regions.Vectors}, name))
+
+	assert.Empty(t, asmprinter.FormatRegion(regions.Region{Data: []byte{0, 0}, Kind: regions.Code}, name))
+}
+
+func TestFormatInst(t *testing.T) {
+	// The input ends part way through the last instruction, leaving an odd
+	// number of bytes.
+	instructions := disassembler.Disassemble([]byte{0x00, 0x00, 0x01, 0x00, 0x6B, 0xFF, 0x7A, 0x00, 0x12})
+	var lines []string
+	for _, inst := range instructions {
+		lines = append(lines, asmprinter.FormatInst(inst))
+	}
+	assert.Equal(t, []string{
+		"00000: 0000                      nop",
+		"00002: 0100                      .word 0x0100 ; invalid",
+		"00004: 6bff                      .word 0x6BFF ; invalid",
+		"00006: 7a00 12                   .byte 0x7A, 0x00, 0x12 ; invalid",
+	}, lines)
+}
+
+func TestFprintInvalid(t *testing.T) {
+	instructions := disassembler.Disassemble([]byte{0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x6B, 0xFF})
+	var out bytes.Buffer
+	assert.NoError(t, asmprinter.FprintInvalid(&out, instructions))
+	assert.Equal(t, `3 invalid, 2 distinct encodings
+.word 0x0100  0000 0001 0000 0000  2 at 00000, 00004
+.word 0x6BFF  0110 1011 1111 1111  1 at 00006
+`, out.String())
+}
@@ -0,0 +1,187 @@
+// contains a headless runner, which runs an emulated machine without any UI
+// until one of a set of conditions is met, so that the emulator can be used to
+// run automated tests of Cybiko software. A condition is written as one of:
+//
+//	pc=<addr>         the PC reaches addr
+//	cycles=<n>        n cycles have been executed
+//	trapa=<n>         a trapa #n is about to be executed
+//	serial=<text>     text has been sent out of the serial port
+//
+// Numbers may be given in decimal, or in hex with 0x.
+package batch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/kn100/cybemu/disassembler"
+	"github.com/kn100/cybemu/machine"
+	"github.com/kn100/cybemu/opcode"
+)
+
+// Exit statuses for a headless run.
+const (
+	// ExitMet means a condition was met.
+	ExitMet = 0
+	// ExitError means the machine stopped with an error.
+	ExitError = 1
+	// ExitTimeout means the cycle limit was reached first.
+	ExitTimeout = 2
+)
+
+// Machine is what the runner drives.
+type Machine interface {
+	machine.Target
+	// Cycles returns the number of cycles executed so far.
+	Cycles() uint64
+	// SerialOutput returns everything sent out of the serial port so far.
+	SerialOutput() []byte
+}
+
+// Screenshotter is implemented by machines with a display.
+type Screenshotter interface {
+	Screenshot() image.Image
+}
+
+// Kind is the kind of a condition.
+type Kind int
+
+const (
+	PC Kind = iota
+	Cycles
+	Trapa
+	Serial
+)
+
+// Condition is something which ends a run.
+type Condition struct {
+	Kind Kind
+	// Value is the address, cycle count or trap number.
+	Value uint64
+	// Text is the serial output waited for.
+	Text string
+}
+
+func (c Condition) String() string {
+	switch c.Kind {
+	case PC:
+		return fmt.Sprintf("pc=0x%06X", c.Value)
+	case Cycles:
+		return fmt.Sprintf("cycles=%d", c.Value)
+	case Trapa:
+		return fmt.Sprintf("trapa=%d", c.Value)
+	}
+	return fmt.Sprintf("serial=%s", c.Text)
+}
+
+// ParseCondition parses a condition as given on the command line.
+func ParseCondition(s string) (Condition, error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return Condition{}, fmt.Errorf("condition %q should look like kind=value", s)
+	}
+	if key == "serial" {
+		if value == "" {
+			return Condition{}, errors.New("serial condition needs some text to wait for")
+		}
+		return Condition{Kind: Serial, Text: value}, nil
+	}
+	kinds := map[string]Kind{"pc": PC, "cycles": Cycles, "trapa": Trapa}
+	kind, ok := kinds[key]
+	if !ok {
+		return Condition{}, fmt.Errorf("unknown condition %q", key)
+	}
+	n, err := strconv.ParseUint(value, 0, 64)
+	if err != nil {
+		return Condition{}, fmt.Errorf("bad number %q in condition", value)
+	}
+	if kind == Trapa && n > 3 {
+		return Condition{}, fmt.Errorf("there is no trapa #%d, only 0 to 3", n)
+	}
+	return Condition{Kind: kind, Value: n}, nil
+}
+
+// Result is how a run ended.
+type Result struct {
+	// Status is one of the exit statuses.
+	Status int
+	// Met is the condition which was met, if Status is ExitMet.
+	Met Condition
+	// Err is why the machine stopped, if Status is ExitError.
+	Err error
+}
+
+// Run steps machine until one of until is met, the machine fails to step, or
+// limit cycles have been executed. A limit of 0 means there is no limit.
+// Conditions are checked before each instruction, so one which is already met
+// ends the run without executing anything.
+func Run(machine Machine, until []Condition, limit uint64) Result {
+	for {
+		if c, ok := met(machine, until); ok {
+			return Result{Status: ExitMet, Met: c}
+		}
+		if limit > 0 && machine.Cycles() >= limit {
+			return Result{Status: ExitTimeout}
+		}
+		if err := machine.Step(); err != nil {
+			return Result{Status: ExitError, Err: err}
+		}
+	}
+}
+
+// met returns the first condition in until which machine meets.
+func met(machine Machine, until []Condition) (Condition, bool) {
+	pc := machine.Registers().PC
+	for _, c := range until {
+		switch c.Kind {
+		case PC:
+			if uint64(pc) == c.Value {
+				return c, true
+			}
+		case Cycles:
+			if machine.Cycles() >= c.Value {
+				return c, true
+			}
+		case Trapa:
+			inst, err := disassembler.DecodeAt(machine, pc)
+			if err != nil || inst.Opcode != opcode.Trapa {
+				continue
+			}
+			if n, ok := inst.Operands()[0].Immediate(); ok && uint64(n.Value) == c.Value {
+				return c, true
+			}
+		case Serial:
+			if bytes.Contains(machine.SerialOutput(), []byte(c.Text)) {
+				return c, true
+			}
+		}
+	}
+	return Condition{}, false
+}
+
+// WriteRegisters writes the registers, one per line as name=value, with the
+// values in hex.
+func WriteRegisters(w io.Writer, regs machine.Registers) error {
+	var b strings.Builder
+	for n, v := range regs.ER {
+		fmt.Fprintf(&b, "er%d=%08x\n", n, v)
+	}
+	fmt.Fprintf(&b, "pc=%06x\nccr=%02x\nexr=%02x\n", regs.PC, regs.CCR, regs.EXR)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteScreenshot writes what machine is displaying to w as a PNG.
+func WriteScreenshot(w io.Writer, machine Machine) error {
+	s, ok := machine.(Screenshotter)
+	if !ok {
+		return errors.New("this machine has no display")
+	}
+	return png.Encode(w, s.Screenshot())
+}
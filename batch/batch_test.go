@@ -0,0 +1,108 @@
+package batch_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/kn100/cybemu/batch"
+	"github.com/kn100/cybemu/cybemutest"
+	"github.com/kn100/cybemu/machine"
+	"github.com/stretchr/testify/assert"
+)
+
+// program returns a machine running the code below, with er0 pointing to
+// the string "PASS" for trapa to send:
+//
+//	100: nop
+//	102: nop
+//	104: trapa #2
+//	106: (invalid)
+func program() *cybemutest.Machine {
+	f := cybemutest.NewProgram([]byte{0x00, 0x00, 0x00, 0x00, 0x57, 0x20, 0xFF, 0xFF})
+	copy(f.Memory[0x40:], "PASS\x00")
+	f.Regs.ER[0] = 0x40
+	return f
+}
+
+func TestParseCondition(t *testing.T) {
+	for s, want := range map[string]batch.Condition{
+		"pc=0x001234":  {Kind: batch.PC, Value: 0x1234},
+		"cycles=11000": {Kind: batch.Cycles, Value: 11000},
+		"trapa=3":      {Kind: batch.Trapa, Value: 3},
+		"serial=a=b":   {Kind: batch.Serial, Text: "a=b"},
+	} {
+		c, err := batch.ParseCondition(s)
+		assert.NoError(t, err)
+		assert.Equal(t, want, c)
+		assert.Equal(t, s, c.String())
+	}
+
+	for s, msg := range map[string]string{
+		"0x1234":     `condition "0x1234" should look like kind=value`,
+		"pc=lots":    `bad number "lots" in condition`,
+		"trapa=4":    "there is no trapa #4, only 0 to 3",
+		"serial=":    "serial condition needs some text to wait for",
+		"sleep=1000": `unknown condition "sleep"`,
+	} {
+		_, err := batch.ParseCondition(s)
+		assert.EqualError(t, err, msg)
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		until  string
+		status int
+		pc     uint32
+	}{
+		{"pc=0x102", batch.ExitMet, 0x102},
+		{"cycles=3", batch.ExitMet, 0x104},
+		{"trapa=2", batch.ExitMet, 0x104},
+		{"trapa=1", batch.ExitError, 0x106},
+		{"serial=PASS", batch.ExitMet, 0x106},
+	} {
+		c, err := batch.ParseCondition(tc.until)
+		assert.NoError(t, err)
+		m := program()
+		result := batch.Run(m, []batch.Condition{c}, 0)
+		assert.Equal(t, tc.status, result.Status, tc.until)
+		assert.Equal(t, tc.pc, m.Regs.PC, tc.until)
+		if tc.status == batch.ExitMet {
+			assert.Equal(t, c, result.Met)
+		} else {
+			assert.EqualError(t, result.Err, "invalid instruction")
+		}
+	}
+
+	m := program()
+	result := batch.Run(m, []batch.Condition{{Kind: batch.PC, Value: 0x200}}, 4)
+	assert.Equal(t, batch.ExitTimeout, result.Status)
+	assert.Equal(t, uint32(0x104), m.Regs.PC)
+}
+
+type screen struct {
+	*cybemutest.Machine
+}
+
+func (screen) Screenshot() image.Image {
+	img := image.NewGray(image.Rect(0, 0, 160, 100))
+	img.Set(3, 4, color.White)
+	return img
+}
+
+func TestDumps(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, batch.WriteRegisters(&out, machine.Registers{ER: [8]uint32{0: 0x12345678, 7: 0xFFFEFC}, PC: 0x104, CCR: 0x84}))
+	assert.Equal(t, "er0=12345678\ner1=00000000\ner2=00000000\ner3=00000000\n"+
+		"er4=00000000\ner5=00000000\ner6=00000000\ner7=00fffefc\npc=000104\nccr=84\nexr=00\n", out.String())
+
+	assert.EqualError(t, batch.WriteScreenshot(&out, program()), "this machine has no display")
+	out.Reset()
+	assert.NoError(t, batch.WriteScreenshot(&out, screen{program()}))
+	img, err := png.Decode(&out)
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 160, 100), img.Bounds())
+}
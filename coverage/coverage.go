@@ -0,0 +1,141 @@
+// contains code coverage for emulated execution: which instructions were
+// executed, how often, and which way each conditional branch went. Coverage is
+// reported against a disassembly, either as an annotated listing or as an
+// lcov style tracefile.
+package coverage
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kn100/cybemu/asmprinter"
+	"github.com/kn100/cybemu/disassembler"
+	"github.com/kn100/cybemu/instruction"
+	"github.com/kn100/cybemu/machine"
+)
+
+// Branch counts the directions a conditional branch went.
+type Branch struct {
+	Taken    uint64
+	NotTaken uint64
+}
+
+// Coverage is the coverage gathered from one or more runs.
+type Coverage struct {
+	// Hits is the number of times the instruction at each address was
+	// executed. Addresses never executed aren't present.
+	Hits map[uint32]uint64
+	// Branches holds the conditional branches executed, by address.
+	Branches map[uint32]*Branch
+}
+
+// New returns an empty Coverage.
+func New() *Coverage {
+	return &Coverage{Hits: map[uint32]uint64{}, Branches: map[uint32]*Branch{}}
+}
+
+// Record notes that inst was executed, after which execution continued at
+// next.
+func (c *Coverage) Record(inst instruction.Inst, next uint32) {
+	pc := uint32(inst.Pos)
+	c.Hits[pc]++
+	if inst.Class()&instruction.Conditional == 0 {
+		return
+	}
+	b := c.Branches[pc]
+	if b == nil {
+		b = &Branch{}
+		c.Branches[pc] = b
+	}
+	if next == pc+uint32(inst.TotalBytes) {
+		b.NotTaken++
+	} else {
+		b.Taken++
+	}
+}
+
+// Target is a machine.Target which records coverage of the instructions it
+// steps.
+type Target struct {
+	machine.Target
+	coverage *Coverage
+}
+
+// NewTarget returns a Target which steps target, recording into coverage.
+func NewTarget(target machine.Target, coverage *Coverage) *Target {
+	return &Target{Target: target, coverage: coverage}
+}
+
+// Step executes a single instruction, recording it if it completes.
+func (t *Target) Step() error {
+	pc := t.Registers().PC
+	inst, readErr := disassembler.DecodeAt(t.Target, pc)
+	if err := t.Target.Step(); err != nil {
+		return err
+	}
+	if readErr != nil {
+		// The instruction can't be decoded, but it did run.
+		t.coverage.Hits[pc]++
+		return nil
+	}
+	t.coverage.Record(inst, t.Registers().PC)
+	return nil
+}
+
+// annotate returns the hit count to print before inst, gcov style, and the
+// directions taken if it is a conditional branch.
+func (c *Coverage) annotate(inst instruction.Inst) (string, string) {
+	pc := uint32(inst.Pos)
+	hits, ok := c.Hits[pc]
+	if !ok {
+		return fmt.Sprintf("%9s: ", "#####"), ""
+	}
+	prefix := fmt.Sprintf("%9d: ", hits)
+	if b := c.Branches[pc]; b != nil {
+		return prefix, fmt.Sprintf("taken %d, not taken %d", b.Taken, b.NotTaken)
+	}
+	return prefix, ""
+}
+
+// WriteListing writes instructions as an annotated listing, each line
+// preceded by the number of times it was executed, or ##### if it never was.
+func (c *Coverage) WriteListing(w io.Writer, instructions []instruction.Inst) error {
+	return asmprinter.FprintAnnotated(w, instructions, c.annotate)
+}
+
+// WriteLCOV writes the coverage of instructions as an lcov tracefile for a
+// source file called name. As there are no source lines, each instruction's
+// address stands in for a line number. Conditional branches each have two
+// branches: 0 is taken and 1 is not taken.
+func (c *Coverage) WriteLCOV(w io.Writer, name string, instructions []instruction.Inst) error {
+	linesHit, branches, branchesHit := 0, 0, 0
+	fmt.Fprintf(w, "TN:\nSF:%s\n", name)
+	for _, inst := range instructions {
+		if inst.Class()&instruction.Conditional == 0 {
+			continue
+		}
+		pc := uint32(inst.Pos)
+		branches += 2
+		b := c.Branches[pc]
+		if b == nil {
+			fmt.Fprintf(w, "BRDA:%d,0,0,-\nBRDA:%d,0,1,-\n", pc, pc)
+			continue
+		}
+		fmt.Fprintf(w, "BRDA:%d,0,0,%d\nBRDA:%d,0,1,%d\n", pc, b.Taken, pc, b.NotTaken)
+		for _, n := range []uint64{b.Taken, b.NotTaken} {
+			if n > 0 {
+				branchesHit++
+			}
+		}
+	}
+	fmt.Fprintf(w, "BRF:%d\nBRH:%d\n", branches, branchesHit)
+	for _, inst := range instructions {
+		hits := c.Hits[uint32(inst.Pos)]
+		if hits > 0 {
+			linesHit++
+		}
+		fmt.Fprintf(w, "DA:%d,%d\n", inst.Pos, hits)
+	}
+	_, err := fmt.Fprintf(w, "LF:%d\nLH:%d\nend_of_record\n", len(instructions), linesHit)
+	return err
+}
@@ -0,0 +1,71 @@
+package coverage_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kn100/cybemu/coverage"
+	"github.com/kn100/cybemu/cybemutest"
+	"github.com/kn100/cybemu/disassembler"
+	"github.com/stretchr/testify/assert"
+)
+
+// program is:
+//
+//	100: add.b #0x80, r0l
+//	102: beq 0x106
+//	104: add.b #0x40, r0l
+//	106: bne 0x102
+//	108: rts
+var program = []byte{0x88, 0x80, 0x47, 0x02, 0x88, 0x40, 0x46, 0xFA, 0x54, 0x70}
+
+// run runs steps instructions of program, starting with r0l holding r0l.
+func run(cov *coverage.Coverage, r0l uint32, steps int) {
+	m := cybemutest.NewProgram(program)
+	m.Regs.ER[0] = r0l
+	t := coverage.NewTarget(m, cov)
+	for n := 0; n < steps; n++ {
+		if t.Step() != nil {
+			return
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	cov := coverage.New()
+	// Stop before rts, as there's nothing to return to.
+	run(cov, 0x80, 3)
+	run(cov, 0x00, 7)
+	assert.Equal(t, map[uint32]uint64{0x100: 2, 0x102: 3, 0x104: 2, 0x106: 3}, cov.Hits)
+	assert.Equal(t, map[uint32]*coverage.Branch{
+		0x102: {Taken: 1, NotTaken: 2},
+		0x106: {Taken: 1, NotTaken: 2},
+	}, cov.Branches)
+
+	insts := disassembler.DisassembleAt(program, 0x100)
+	var listing bytes.Buffer
+	assert.NoError(t, cov.WriteListing(&listing, insts))
+	assert.Equal(t, ""+
+		"        2: 00100: 8880                      add.b #0x80, r0l\n"+
+		"        3: 00102: 4702                      beq 0x00000106:8 ; taken 1, not taken 2\n"+
+		"        2: 00104: 8840                      add.b #0x40, r0l\n"+
+		"        3: 00106: 46fa                      bne 0x00000102:8 ; taken 1, not taken 2\n"+
+		"    #####: 00108: 5470                      rts\n",
+		listing.String())
+
+	var lcov bytes.Buffer
+	assert.NoError(t, cov.WriteLCOV(&lcov, "boot.bin", insts))
+	assert.Equal(t, "TN:\nSF:boot.bin\n"+
+		"BRDA:258,0,0,1\nBRDA:258,0,1,2\nBRDA:262,0,0,1\nBRDA:262,0,1,2\nBRF:4\nBRH:4\n"+
+		"DA:256,2\nDA:258,3\nDA:260,2\nDA:262,3\nDA:264,0\nLF:5\nLH:4\nend_of_record\n",
+		lcov.String())
+}
+
+func TestUnexecutedBranch(t *testing.T) {
+	var lcov bytes.Buffer
+	insts := disassembler.DisassembleAt(program, 0x100)
+	assert.NoError(t, coverage.New().WriteLCOV(&lcov, "boot.bin", insts))
+	assert.Contains(t, lcov.String(), "BRDA:258,0,0,-\nBRDA:258,0,1,-\n")
+	assert.Contains(t, lcov.String(), "BRF:4\nBRH:0\n")
+	assert.Contains(t, lcov.String(), "LF:5\nLH:0\n")
+}
@@ -0,0 +1,144 @@
+// contains helpers for tests which run code on an emulated machine, such as
+// tests of instruction semantics. A snippet of machine code is loaded, called
+// as a subroutine with the registers it should start with, and run until it
+// returns with rts, after which the registers, flags and memory it left can be
+// checked.
+//
+// There's no assembler in this repository, so snippets are given as machine
+// code, with the assembly notation kept alongside in a comment.
+//
+// It also has Machine, a fake target understanding a handful of instructions,
+// for tests of the tools which drive a machine rather than of the machine.
+package cybemutest
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/kn100/cybemu/machine"
+	"github.com/stretchr/testify/assert"
+)
+
+// Where snippets are loaded and run, in the Cybiko's external RAM.
+const (
+	// CodeAddr is where the snippet is loaded.
+	CodeAddr = 0x200000
+	// StackTop is the stack pointer a snippet starts with, unless the
+	// registers it is given say otherwise.
+	StackTop = 0x210000
+	// ReturnAddr is the return address the snippet is called with. Nothing is
+	// executed there; reaching it ends the run.
+	ReturnAddr = 0x20FF00
+)
+
+// MaxSteps is the most instructions a snippet may execute before it is
+// assumed to be stuck.
+const MaxSteps = 100000
+
+// The flags in CCR, by name.
+var flags = map[string]byte{
+	"i":  0x80,
+	"ui": 0x40,
+	"h":  0x20,
+	"u":  0x10,
+	"n":  0x08,
+	"z":  0x04,
+	"v":  0x02,
+	"c":  0x01,
+}
+
+// Result is the state a snippet left the machine in.
+type Result struct {
+	Regs machine.Registers
+	// Steps is the number of instructions executed, including the rts.
+	Steps  int
+	target machine.Target
+}
+
+// RunSnippet loads code into target and calls it with the registers in setup,
+// returning once it has returned. PC is always CodeAddr, and a stack pointer
+// of 0 is replaced with StackTop. The test fails immediately if the snippet
+// can't be run, stops with an error, or doesn't return within MaxSteps.
+func RunSnippet(t testing.TB, target machine.Target, code []byte, setup machine.Registers) Result {
+	t.Helper()
+	if err := target.WriteMemory(CodeAddr, code); err != nil {
+		t.Fatalf("couldn't load snippet: %s", err)
+	}
+	regs := setup
+	regs.PC = CodeAddr
+	if regs.ER[7] == 0 {
+		regs.ER[7] = StackTop
+	}
+	sp := regs.ER[7]
+	regs.ER[7] -= 4
+	ret := make([]byte, 4)
+	binary.BigEndian.PutUint32(ret, ReturnAddr)
+	if err := target.WriteMemory(regs.ER[7], ret); err != nil {
+		t.Fatalf("couldn't push return address: %s", err)
+	}
+	target.SetRegisters(regs)
+
+	for steps := 1; steps <= MaxSteps; steps++ {
+		if err := target.Step(); err != nil {
+			t.Fatalf("snippet stopped at 0x%06X after %d instructions: %s", target.Registers().PC, steps-1, err)
+		}
+		regs := target.Registers()
+		if regs.PC == ReturnAddr && regs.ER[7] == sp {
+			return Result{Regs: regs, Steps: steps, target: target}
+		}
+	}
+	t.Fatalf("snippet didn't return within %d instructions", MaxSteps)
+	return Result{}
+}
+
+// Memory returns length bytes of memory starting at addr, failing the test
+// if they can't be read.
+func (r Result) Memory(t testing.TB, addr uint32, length int) []byte {
+	t.Helper()
+	buf := make([]byte, length)
+	if err := r.target.ReadMemory(addr, buf); err != nil {
+		t.Fatalf("couldn't read 0x%06X: %s", addr, err)
+	}
+	return buf
+}
+
+// Flag returns whether the named CCR flag (i, ui, h, u, n, z, v or c) is set.
+// It panics if there is no such flag.
+func (r Result) Flag(name string) bool {
+	bit, ok := flags[strings.ToLower(name)]
+	if !ok {
+		panic("no such flag " + name)
+	}
+	return r.Regs.CCR&bit != 0
+}
+
+// AssertFlags asserts that, of the arithmetic flags h, n, z, v and c, exactly
+// those named in set are set. set holds flag names separated by spaces, such
+// as "z c".
+func AssertFlags(t testing.TB, r Result, set string, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	want := map[string]bool{}
+	for _, name := range strings.Fields(strings.ToLower(set)) {
+		want[name] = true
+	}
+	var gotSet, wantSet []string
+	for _, name := range []string{"h", "n", "z", "v", "c"} {
+		if r.Flag(name) {
+			gotSet = append(gotSet, name)
+		}
+		if want[name] {
+			wantSet = append(wantSet, name)
+		}
+	}
+	return assert.Equal(t, strings.Join(wantSet, " "), strings.Join(gotSet, " "), msgAndArgs...)
+}
+
+// AssertReg asserts that ern holds value.
+func AssertReg(t testing.TB, r Result, n int, value uint32, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if len(msgAndArgs) == 0 {
+		msgAndArgs = []interface{}{"er%d is 0x%08X, not 0x%08X", n, r.Regs.ER[n], value}
+	}
+	return assert.Equal(t, value, r.Regs.ER[n], msgAndArgs...)
+}
@@ -0,0 +1,104 @@
+package cybemutest_test
+
+import (
+	"testing"
+
+	"github.com/kn100/cybemu/cybemutest"
+	"github.com/kn100/cybemu/machine"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTarget returns a Machine with memory up to the top of the stack.
+func newTarget() *cybemutest.Machine {
+	return cybemutest.NewMachine(cybemutest.StackTop)
+}
+
+func TestRunSnippet(t *testing.T) {
+	target := newTarget()
+	r := cybemutest.RunSnippet(t, target, []byte{
+		0x00, 0x00, // nop
+		0x8A, 0x80, // add.b #0x80, r2l
+		0x54, 0x70, // rts
+	}, machine.Registers{ER: [8]uint32{2: 0x12345680}})
+	assert.Equal(t, 3, r.Steps)
+	cybemutest.AssertReg(t, r, 2, 0x12345600)
+	cybemutest.AssertFlags(t, r, "Z V C")
+	assert.True(t, r.Flag("c"))
+	assert.False(t, r.Flag("N"))
+	assert.Equal(t, uint32(cybemutest.StackTop), r.Regs.ER[7])
+	assert.Equal(t, []byte{0x00, 0x20, 0xFF, 0x00}, r.Memory(t, cybemutest.StackTop-4, 4), "the return address")
+	assert.Panics(t, func() { r.Flag("q") })
+
+	r = cybemutest.RunSnippet(t, target, []byte{
+		0x8A, 0x71, // add.b #0x71, r2l
+		0x54, 0x70, // rts
+	}, machine.Registers{ER: [8]uint32{2: 0x0F}})
+	cybemutest.AssertReg(t, r, 2, 0x80)
+	cybemutest.AssertFlags(t, r, "H N V")
+
+	r = cybemutest.RunSnippet(t, target, []byte{0x54, 0x70}, machine.Registers{ER: [8]uint32{7: 0x208000}})
+	assert.Equal(t, uint32(0x208000), r.Regs.ER[7])
+	cybemutest.AssertFlags(t, r, "")
+}
+
+// failures records failures rather than failing the test, so tests can check
+// that RunSnippet reports them.
+type failures struct {
+	testing.TB
+	fatal string
+}
+
+func (f *failures) Helper() {}
+
+func (f *failures) Fatalf(format string, args ...interface{}) {
+	f.fatal = format
+	// RunSnippet must not carry on after Fatalf.
+	panic(f)
+}
+
+func runFailing(tb testing.TB, code []byte) string {
+	f := &failures{TB: tb}
+	func() {
+		defer func() {
+			if r := recover(); r != f {
+				panic(r)
+			}
+		}()
+		cybemutest.RunSnippet(f, newTarget(), code, machine.Registers{})
+	}()
+	return f.fatal
+}
+
+func TestSnippetFailures(t *testing.T) {
+	assert.Equal(t, "snippet stopped at 0x%06X after %d instructions: %s", runFailing(t, []byte{0xFF, 0xFF}))
+	assert.Equal(t, "snippet didn't return within %d instructions", runFailing(t, []byte{0x40, 0xFE}))
+}
+
+func TestMachine(t *testing.T) {
+	m := cybemutest.NewMachine(0x200)
+	copy(m.Memory[0x40:], "OK\x00")
+	copy(m.Memory[0x100:], []byte{
+		0x55, 0x02, // 100: bsr 0x104
+		0xFF, 0xFF, // 102: (invalid)
+		0x57, 0x00, // 104: trapa #0
+		0x54, 0x70, // 106: rts
+	})
+	m.Regs = machine.Registers{ER: [8]uint32{0: 0x40, 7: 0x200}, PC: 0x100}
+	before, err := m.Snapshot()
+	assert.NoError(t, err)
+
+	for n := 0; n < 3; n++ {
+		assert.NoError(t, m.Step())
+	}
+	assert.Equal(t, uint32(0x102), m.Regs.PC)
+	assert.Equal(t, uint32(0x200), m.Regs.ER[7])
+	assert.Equal(t, uint64(22), m.Cycles())
+	assert.Equal(t, "OK", string(m.SerialOutput()))
+	assert.EqualError(t, m.Step(), "invalid instruction")
+	assert.Equal(t, uint32(0x102), m.Regs.PC)
+	assert.Error(t, m.ReadMemory(0x1FF, make([]byte, 2)))
+
+	assert.NoError(t, m.Restore(before))
+	assert.Equal(t, uint32(0x100), m.Regs.PC)
+	assert.Equal(t, uint64(0), m.Cycles())
+}
@@ -0,0 +1,195 @@
+package cybemutest
+
+import (
+	"errors"
+
+	"github.com/kn100/cybemu/machine"
+	"github.com/kn100/cybemu/snapshot"
+)
+
+// errInvalid is returned by Machine.Step for anything it doesn't understand.
+var errInvalid = errors.New("invalid instruction")
+
+// Machine is a tiny stand-in for the emulator, for testing the tools which
+// drive one, such as the debugger stub, monitor, profiler and runner. Its
+// memory starts at address 0, and reading or writing past the end of it
+// fails. It understands just these instructions, which take the cycles given:
+//
+//	00 00  nop                2
+//	40 dd  bra d:8            4
+//	46 dd  bne d:8            4
+//	47 dd  beq d:8            4
+//	55 dd  bsr d:8            6
+//	54 70  rts                8
+//	3r aa  mov.b r, @aa:8     4, writing to aa rather than the top of memory
+//	8r ii  add.b #ii, r       2, for r0l to r7l only
+//	57 i0  trapa #i           8, sending the null terminated string at er0
+//	                             out of the serial port
+//
+// Anything else fails with "invalid instruction", leaving PC where it was.
+type Machine struct {
+	Regs   machine.Registers
+	Memory []byte
+	cycles uint64
+	serial []byte
+}
+
+// ProgramAddr is where NewProgram loads code.
+const ProgramAddr = 0x100
+
+// NewProgram returns a Machine with 0x200 bytes of memory, holding code at
+// ProgramAddr, where PC points, and with the stack at the top of memory.
+func NewProgram(code []byte) *Machine {
+	m := NewMachine(0x200)
+	copy(m.Memory[ProgramAddr:], code)
+	m.Regs.PC = ProgramAddr
+	m.Regs.ER[7] = 0x1F0
+	return m
+}
+
+// NewMachine returns a Machine with size bytes of memory, all zero, so that
+// it holds nothing but nops.
+func NewMachine(size int) *Machine {
+	return &Machine{Memory: make([]byte, size)}
+}
+
+func (m *Machine) Registers() machine.Registers        { return m.Regs }
+func (m *Machine) SetRegisters(regs machine.Registers) { m.Regs = regs }
+
+// Cycles returns the number of cycles executed so far.
+func (m *Machine) Cycles() uint64 { return m.cycles }
+
+// SerialOutput returns everything trapa has sent out of the serial port.
+func (m *Machine) SerialOutput() []byte { return m.serial }
+
+func (m *Machine) ReadMemory(addr uint32, buf []byte) error {
+	if uint64(addr)+uint64(len(buf)) > uint64(len(m.Memory)) {
+		return errors.New("out of range")
+	}
+	copy(buf, m.Memory[addr:])
+	return nil
+}
+
+func (m *Machine) WriteMemory(addr uint32, data []byte) error {
+	if uint64(addr)+uint64(len(data)) > uint64(len(m.Memory)) {
+		return errors.New("out of range")
+	}
+	copy(m.Memory[addr:], data)
+	return nil
+}
+
+// Step executes the instruction at PC.
+func (m *Machine) Step() error {
+	pc := m.Regs.PC
+	code := make([]byte, 2)
+	if m.ReadMemory(pc, code) != nil {
+		return errInvalid
+	}
+	a, b := code[0], code[1]
+	next := pc + 2
+	branch := uint32(int(next) + int(int8(b)))
+	zero := m.Regs.CCR&0x04 != 0
+	cycles := uint64(4)
+	switch {
+	case a == 0x00 && b == 0x00:
+		cycles = 2
+	case a == 0x40:
+		next = branch
+	case a == 0x46 && !zero, a == 0x47 && zero:
+		next = branch
+	case a == 0x46, a == 0x47:
+	case a == 0x55:
+		if m.push(next) != nil {
+			return errInvalid
+		}
+		next, cycles = branch, 6
+	case a == 0x54 && b == 0x70:
+		ret, err := m.pop()
+		if err != nil {
+			return errInvalid
+		}
+		next, cycles = ret, 8
+	case a>>4 == 0x3:
+		if m.WriteMemory(uint32(b), []byte{m.byteRegister(a & 0xF)}) != nil {
+			return errInvalid
+		}
+	case a>>3 == 0x11:
+		reg := &m.Regs.ER[a&0x7]
+		x, y := byte(*reg), b
+		sum := x + y
+		*reg = *reg&^0xFF | uint32(sum)
+		m.Regs.CCR &^= 0x2F
+		if x&0xF+y&0xF > 0xF {
+			m.Regs.CCR |= 0x20
+		}
+		if sum&0x80 != 0 {
+			m.Regs.CCR |= 0x08
+		}
+		if sum == 0 {
+			m.Regs.CCR |= 0x04
+		}
+		if (x^sum)&(y^sum)&0x80 != 0 {
+			m.Regs.CCR |= 0x02
+		}
+		if sum < x {
+			m.Regs.CCR |= 0x01
+		}
+		cycles = 2
+	case a == 0x57 && b&0xCF == 0:
+		for addr := m.Regs.ER[0]; int(addr) < len(m.Memory) && m.Memory[addr] != 0; addr++ {
+			m.serial = append(m.serial, m.Memory[addr])
+		}
+		cycles = 8
+	default:
+		return errInvalid
+	}
+	m.Regs.PC = next & 0xFFFFFF
+	m.cycles += cycles
+	return nil
+}
+
+// byteRegister returns r0h to r7l, numbered 0 to 15.
+func (m *Machine) byteRegister(n byte) byte {
+	if n < 8 {
+		return byte(m.Regs.ER[n] >> 8)
+	}
+	return byte(m.Regs.ER[n&7])
+}
+
+func (m *Machine) push(v uint32) error {
+	sp := m.Regs.ER[7] - 4
+	if err := m.WriteMemory(sp, []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}); err != nil {
+		return err
+	}
+	m.Regs.ER[7] = sp
+	return nil
+}
+
+func (m *Machine) pop() (uint32, error) {
+	buf := make([]byte, 4)
+	if err := m.ReadMemory(m.Regs.ER[7], buf); err != nil {
+		return 0, err
+	}
+	m.Regs.ER[7] += 4
+	return uint32(buf[1])<<16 | uint32(buf[2])<<8 | uint32(buf[3]), nil
+}
+
+// Snapshot returns the registers, cycle count and memory of m.
+func (m *Machine) Snapshot() (*snapshot.Snapshot, error) {
+	return &snapshot.Snapshot{
+		Cycle:     m.cycles,
+		Registers: m.Regs,
+		Memory:    []snapshot.Region{{Data: append([]byte{}, m.Memory...)}},
+	}, nil
+}
+
+// Restore puts m back in the state s was taken in.
+func (m *Machine) Restore(s *snapshot.Snapshot) error {
+	if len(s.Memory) != 1 || len(s.Memory[0].Data) != len(m.Memory) {
+		return errors.New("snapshot isn't of this machine")
+	}
+	m.cycles = s.Cycle
+	m.Regs = s.Registers
+	copy(m.Memory, s.Memory[0].Data)
+	return nil
+}
@@ -0,0 +1,273 @@
+// contains a model of the Atmel AT45DB041 serial DataFlash the Cybiko Classic
+// keeps CyOS and user files on. It follows the command set described in the
+// "AT45DB041B 4-megabit DataFlash" datasheet, and is driven a byte at a time
+// by whatever emulates the synchronous serial channel it sits on.
+package dataflash
+
+const (
+	// PageSize is the number of bytes in a main memory page, and in each of
+	// the two SRAM buffers.
+	PageSize = 264
+	// Pages is the number of main memory pages.
+	Pages = 2048
+	// BlockPages is the number of pages erased by a block erase.
+	BlockPages = 8
+	// Size is the number of bytes in the main memory.
+	Size = PageSize * Pages
+)
+
+// Commands understood by the device. Where the datasheet lists both a legacy
+// and an SPI mode opcode for the same command, both are accepted.
+const (
+	CmdContinuousArrayRead       = 0x68
+	CmdContinuousArrayReadSPI    = 0xE8
+	CmdPageRead                  = 0x52
+	CmdPageReadSPI               = 0xD2
+	CmdBuffer1Read               = 0x54
+	CmdBuffer1ReadSPI            = 0xD4
+	CmdBuffer2Read               = 0x56
+	CmdBuffer2ReadSPI            = 0xD6
+	CmdStatusRead                = 0x57
+	CmdStatusReadSPI             = 0xD7
+	CmdBuffer1Write              = 0x84
+	CmdBuffer2Write              = 0x87
+	CmdBuffer1ProgramWithErase   = 0x83
+	CmdBuffer2ProgramWithErase   = 0x86
+	CmdBuffer1Program            = 0x88
+	CmdBuffer2Program            = 0x89
+	CmdPageErase                 = 0x81
+	CmdBlockErase                = 0x50
+	CmdPageProgramThroughBuffer1 = 0x82
+	CmdPageProgramThroughBuffer2 = 0x85
+	CmdPageToBuffer1Transfer     = 0x53
+	CmdPageToBuffer2Transfer     = 0x55
+	CmdPageToBuffer1Compare      = 0x60
+	CmdPageToBuffer2Compare      = 0x61
+	CmdAutoRewriteBuffer1        = 0x58
+	CmdAutoRewriteBuffer2        = 0x59
+)
+
+const (
+	// statusReady is set in the status register when the device isn't busy.
+	// Operations complete instantly here, so it is always set.
+	statusReady = 0x80
+	// statusCompareMismatch is set when the last compare found a difference.
+	statusCompareMismatch = 0x40
+	// statusDensity is the density code of the AT45DB041 (0111), as found in
+	// bits 5 through 2 of the status register.
+	statusDensity = 0x1C
+)
+
+// Device is a single AT45DB041. Commands are sent by calling Select, then
+// Transfer for every byte clocked in and out, then Deselect. Program and erase
+// commands take effect on Deselect, as they do when chip select goes high on
+// the real part.
+type Device struct {
+	image   *Image
+	buffers [2][PageSize]byte
+	compare bool // true if the last compare found a mismatch
+	err     error
+
+	selected   bool
+	haveOpcode bool
+	opcode     byte
+	header     []byte // bytes received after the opcode
+	page       int
+	offset     int
+	pageBuf    [PageSize]byte // page being streamed out by a read
+}
+
+// New returns a Device which stores its main memory in image.
+func New(image *Image) *Device {
+	return &Device{image: image}
+}
+
+// Image returns the storage array of the Device.
+func (d *Device) Image() *Image {
+	return d.image
+}
+
+// Err returns the first error encountered while accessing the Image, if any.
+// The serial interface has no way to report these, so they are kept here.
+func (d *Device) Err() error {
+	return d.err
+}
+
+// Status returns the value of the status register.
+func (d *Device) Status() byte {
+	status := byte(statusReady | statusDensity)
+	if d.compare {
+		status |= statusCompareMismatch
+	}
+	return status
+}
+
+// Buffer returns a copy of the SRAM buffer n (1 or 2).
+func (d *Device) Buffer(n int) [PageSize]byte {
+	return d.buffers[n-1]
+}
+
+// Select asserts chip select, starting a new command.
+func (d *Device) Select() {
+	d.selected = true
+	d.haveOpcode = false
+	d.header = d.header[:0]
+}
+
+// Deselect releases chip select, completing the current command.
+func (d *Device) Deselect() {
+	if !d.selected {
+		return
+	}
+	d.selected = false
+	if !d.haveOpcode || len(d.header) < headerLength(d.opcode) {
+		// Aborted before the whole command was clocked in.
+		return
+	}
+	switch d.opcode {
+	case CmdBuffer1ProgramWithErase, CmdBuffer2ProgramWithErase,
+		CmdPageProgramThroughBuffer1, CmdPageProgramThroughBuffer2:
+		d.writePage(d.page, d.buffers[bufferIndex(d.opcode)][:])
+	case CmdBuffer1Program, CmdBuffer2Program:
+		d.readPage(d.page, d.pageBuf[:])
+		buf := d.buffers[bufferIndex(d.opcode)]
+		for b := range d.pageBuf {
+			// Without an erase, programming can only clear bits.
+			d.pageBuf[b] &= buf[b]
+		}
+		d.writePage(d.page, d.pageBuf[:])
+	case CmdPageErase:
+		d.erase(d.page, 1)
+	case CmdBlockErase:
+		d.erase(d.page&^(BlockPages-1), BlockPages)
+	case CmdPageToBuffer1Transfer, CmdPageToBuffer2Transfer:
+		d.readPage(d.page, d.buffers[bufferIndex(d.opcode)][:])
+	case CmdPageToBuffer1Compare, CmdPageToBuffer2Compare:
+		d.readPage(d.page, d.pageBuf[:])
+		d.compare = d.pageBuf != d.buffers[bufferIndex(d.opcode)]
+	case CmdAutoRewriteBuffer1, CmdAutoRewriteBuffer2:
+		n := bufferIndex(d.opcode)
+		d.readPage(d.page, d.buffers[n][:])
+		d.writePage(d.page, d.buffers[n][:])
+	}
+}
+
+// Transfer clocks a byte into the device, and returns the byte it clocked out
+// at the same time. While the device is deselected, or has nothing to say,
+// the line floats high and 0xFF is returned.
+func (d *Device) Transfer(in byte) byte {
+	if !d.selected {
+		return 0xFF
+	}
+	if !d.haveOpcode {
+		d.haveOpcode = true
+		d.opcode = in
+		return 0xFF
+	}
+	if len(d.header) < headerLength(d.opcode) {
+		d.header = append(d.header, in)
+		d.startIfReady()
+		return 0xFF
+	}
+
+	switch d.opcode {
+	case CmdStatusRead, CmdStatusReadSPI:
+		return d.Status()
+	case CmdPageRead, CmdPageReadSPI:
+		out := d.pageBuf[d.offset]
+		d.offset = (d.offset + 1) % PageSize
+		return out
+	case CmdContinuousArrayRead, CmdContinuousArrayReadSPI:
+		out := d.pageBuf[d.offset]
+		d.offset++
+		if d.offset == PageSize {
+			d.offset = 0
+			d.page = (d.page + 1) % Pages
+			d.readPage(d.page, d.pageBuf[:])
+		}
+		return out
+	case CmdBuffer1Read, CmdBuffer1ReadSPI, CmdBuffer2Read, CmdBuffer2ReadSPI:
+		out := d.buffers[bufferIndex(d.opcode)][d.offset]
+		d.offset = (d.offset + 1) % PageSize
+		return out
+	case CmdBuffer1Write, CmdBuffer2Write,
+		CmdPageProgramThroughBuffer1, CmdPageProgramThroughBuffer2:
+		d.buffers[bufferIndex(d.opcode)][d.offset] = in
+		d.offset = (d.offset + 1) % PageSize
+	}
+	return 0xFF
+}
+
+// startIfReady decodes the address once the whole command header has been
+// received, and preloads whatever page a read will stream out.
+func (d *Device) startIfReady() {
+	if len(d.header) != headerLength(d.opcode) || len(d.header) < 3 {
+		return
+	}
+	// 4 reserved bits, 11 page address bits, 9 byte address bits.
+	address := int(d.header[0])<<16 | int(d.header[1])<<8 | int(d.header[2])
+	d.page = (address >> 9) & (Pages - 1)
+	d.offset = (address & 0x1FF) % PageSize
+	switch d.opcode {
+	case CmdPageRead, CmdPageReadSPI, CmdContinuousArrayRead, CmdContinuousArrayReadSPI:
+		d.readPage(d.page, d.pageBuf[:])
+	}
+}
+
+func (d *Device) readPage(page int, buf []byte) {
+	if err := d.image.ReadPage(page, buf); err != nil && d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *Device) writePage(page int, data []byte) {
+	if err := d.image.WritePage(page, data); err != nil && d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *Device) erase(first int, count int) {
+	erased := make([]byte, PageSize)
+	for b := range erased {
+		erased[b] = 0xFF
+	}
+	for page := first; page < first+count; page++ {
+		d.writePage(page, erased)
+	}
+}
+
+// headerLength returns the number of address and don't care bytes which
+// follow opcode before any data is transferred.
+func headerLength(opcode byte) int {
+	switch opcode {
+	case CmdStatusRead, CmdStatusReadSPI:
+		return 0
+	case CmdPageRead, CmdPageReadSPI, CmdContinuousArrayRead, CmdContinuousArrayReadSPI:
+		return 7
+	case CmdBuffer1Read, CmdBuffer1ReadSPI, CmdBuffer2Read, CmdBuffer2ReadSPI:
+		return 4
+	case CmdBuffer1Write, CmdBuffer2Write,
+		CmdBuffer1ProgramWithErase, CmdBuffer2ProgramWithErase,
+		CmdBuffer1Program, CmdBuffer2Program,
+		CmdPageErase, CmdBlockErase,
+		CmdPageProgramThroughBuffer1, CmdPageProgramThroughBuffer2,
+		CmdPageToBuffer1Transfer, CmdPageToBuffer2Transfer,
+		CmdPageToBuffer1Compare, CmdPageToBuffer2Compare,
+		CmdAutoRewriteBuffer1, CmdAutoRewriteBuffer2:
+		return 3
+	}
+	// Unknown opcodes never complete, so everything after them is ignored.
+	return -1
+}
+
+// bufferIndex returns which of the two SRAM buffers opcode operates on.
+func bufferIndex(opcode byte) int {
+	switch opcode {
+	case CmdBuffer2Read, CmdBuffer2ReadSPI, CmdBuffer2Write,
+		CmdBuffer2ProgramWithErase, CmdBuffer2Program,
+		CmdPageProgramThroughBuffer2, CmdPageToBuffer2Transfer,
+		CmdPageToBuffer2Compare, CmdAutoRewriteBuffer2:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,169 @@
+package dataflash_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kn100/cybemu/dataflash"
+	"github.com/stretchr/testify/assert"
+)
+
+// command runs a whole command against d, clocking out as many bytes as are
+// clocked in.
+func command(d *dataflash.Device, in ...byte) []byte {
+	out := make([]byte, len(in))
+	d.Select()
+	for i, b := range in {
+		out[i] = d.Transfer(b)
+	}
+	d.Deselect()
+	return out
+}
+
+// address builds the three address bytes for a page and byte offset.
+func address(page int, offset int) []byte {
+	a := page<<9 | offset
+	return []byte{byte(a >> 16), byte(a >> 8), byte(a)}
+}
+
+func testImage() []byte {
+	image := make([]byte, dataflash.Size)
+	for i := range image {
+		image[i] = byte(i / dataflash.PageSize)
+	}
+	return image
+}
+
+func TestStatusRead(t *testing.T) {
+	d := dataflash.New(dataflash.NewImage(nil))
+	out := command(d, dataflash.CmdStatusRead, 0, 0)
+	assert.Equal(t, []byte{0xFF, 0x9C, 0x9C}, out)
+	out = command(d, dataflash.CmdStatusReadSPI, 0)
+	assert.Equal(t, []byte{0xFF, 0x9C}, out)
+}
+
+func TestPageRead(t *testing.T) {
+	image := testImage()
+	image[5*dataflash.PageSize+263] = 0xAA
+	d := dataflash.New(dataflash.NewImage(bytes.NewReader(image)))
+
+	in := append([]byte{dataflash.CmdPageRead}, address(5, 262)...)
+	in = append(in, 0, 0, 0, 0) // don't care
+	in = append(in, 0, 0, 0)
+	out := command(d, in...)
+	// Reads wrap around to the start of the same page.
+	assert.Equal(t, []byte{0x05, 0xAA, 0x05}, out[8:])
+}
+
+func TestContinuousArrayRead(t *testing.T) {
+	d := dataflash.New(dataflash.NewImage(bytes.NewReader(testImage())))
+
+	in := append([]byte{dataflash.CmdContinuousArrayRead}, address(dataflash.Pages-1, 263)...)
+	in = append(in, 0, 0, 0, 0, 0, 0)
+	out := command(d, in...)
+	// Reads continue across pages, and wrap around the end of the array.
+	assert.Equal(t, []byte{byte((dataflash.Pages - 1) & 0xFF), 0x00}, out[8:])
+}
+
+func TestBufferWriteAndRead(t *testing.T) {
+	d := dataflash.New(dataflash.NewImage(nil))
+
+	command(d, append([]byte{dataflash.CmdBuffer2Write}, append(address(0, 10), 1, 2, 3)...)...)
+	in := append([]byte{dataflash.CmdBuffer2Read}, address(0, 9)...)
+	in = append(in, 0, 0, 0, 0, 0, 0)
+	out := command(d, in...)
+	assert.Equal(t, []byte{0x00, 1, 2, 3, 0x00}, out[5:])
+
+	buf := d.Buffer(1)
+	assert.Equal(t, byte(0), buf[10], "buffer 1 should not have been touched")
+}
+
+func TestProgramAndErase(t *testing.T) {
+	d := dataflash.New(dataflash.NewImage(bytes.NewReader(testImage())))
+	page := make([]byte, dataflash.PageSize)
+
+	command(d, append([]byte{dataflash.CmdBuffer1Write}, append(address(0, 0), 0x0F, 0xF0)...)...)
+	command(d, append([]byte{dataflash.CmdBuffer1ProgramWithErase}, address(7, 0)...)...)
+	assert.NoError(t, d.Image().ReadPage(7, page))
+	assert.Equal(t, []byte{0x0F, 0xF0, 0x00}, page[:3])
+
+	// Programming without an erase can only clear bits.
+	command(d, append([]byte{dataflash.CmdBuffer1Write}, append(address(0, 0), 0xFF, 0x3C, 0xFF)...)...)
+	command(d, append([]byte{dataflash.CmdBuffer1Program}, address(7, 0)...)...)
+	assert.NoError(t, d.Image().ReadPage(7, page))
+	assert.Equal(t, []byte{0x0F, 0x30, 0x00}, page[:3])
+
+	command(d, append([]byte{dataflash.CmdPageErase}, address(7, 0)...)...)
+	assert.NoError(t, d.Image().ReadPage(7, page))
+	assert.Equal(t, bytes.Repeat([]byte{0xFF}, dataflash.PageSize), page)
+
+	command(d, append([]byte{dataflash.CmdBlockErase}, address(13, 0)...)...)
+	for p := 8; p < 16; p++ {
+		assert.NoError(t, d.Image().ReadPage(p, page))
+		assert.Equal(t, byte(0xFF), page[0], "page %d should be erased", p)
+	}
+	assert.NoError(t, d.Image().ReadPage(16, page))
+	assert.Equal(t, byte(16), page[0])
+	assert.Equal(t, []int{7, 8, 9, 10, 11, 12, 13, 14, 15}, d.Image().DirtyPages())
+}
+
+func TestAbortedCommandDoesNothing(t *testing.T) {
+	d := dataflash.New(dataflash.NewImage(bytes.NewReader(testImage())))
+	command(d, dataflash.CmdPageErase, 0x00, 0x0E)
+	assert.Empty(t, d.Image().DirtyPages())
+}
+
+func TestPageProgramThroughBuffer(t *testing.T) {
+	d := dataflash.New(dataflash.NewImage(nil))
+	command(d, append([]byte{dataflash.CmdPageProgramThroughBuffer1}, append(address(3, 1), 0x12, 0x34)...)...)
+	page := make([]byte, dataflash.PageSize)
+	assert.NoError(t, d.Image().ReadPage(3, page))
+	assert.Equal(t, []byte{0x00, 0x12, 0x34, 0x00}, page[:4])
+}
+
+func TestTransferAndCompare(t *testing.T) {
+	d := dataflash.New(dataflash.NewImage(bytes.NewReader(testImage())))
+
+	command(d, append([]byte{dataflash.CmdPageToBuffer1Transfer}, address(42, 0)...)...)
+	buf := d.Buffer(1)
+	assert.Equal(t, byte(42), buf[0])
+
+	command(d, append([]byte{dataflash.CmdPageToBuffer1Compare}, address(42, 0)...)...)
+	assert.Equal(t, byte(0x9C), d.Status())
+	command(d, append([]byte{dataflash.CmdPageToBuffer1Compare}, address(43, 0)...)...)
+	assert.Equal(t, byte(0xDC), d.Status())
+}
+
+func TestImageIsNotModified(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flash.bin")
+	golden := testImage()[:dataflash.PageSize*4]
+	assert.NoError(t, os.WriteFile(path, golden, 0o644))
+
+	im, err := dataflash.OpenImage(path)
+	assert.NoError(t, err)
+	d := dataflash.New(im)
+	command(d, append([]byte{dataflash.CmdPageErase}, address(1, 0)...)...)
+	assert.NoError(t, d.Err())
+
+	var saved bytes.Buffer
+	n, err := im.WriteTo(&saved)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(dataflash.Size), n)
+	assert.Equal(t, bytes.Repeat([]byte{0xFF}, dataflash.PageSize), saved.Bytes()[dataflash.PageSize:2*dataflash.PageSize])
+	// Pages past the end of a short image read as erased.
+	assert.Equal(t, byte(0xFF), saved.Bytes()[dataflash.PageSize*4])
+	assert.NoError(t, im.Close())
+
+	onDisk, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, golden, onDisk)
+}
+
+func TestOpenImageTooLarge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flash.bin")
+	assert.NoError(t, os.WriteFile(path, make([]byte, dataflash.Size+1), 0o644))
+	_, err := dataflash.OpenImage(path)
+	assert.Error(t, err)
+}
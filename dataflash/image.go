@@ -0,0 +1,143 @@
+package dataflash
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Image is the storage array behind a Device. Pages are read from a backing
+// image (usually a dump of a real Cybiko's flash) and any page written by the
+// emulated device is kept in an in-memory overlay instead, so the backing
+// image is never modified. Bytes missing from a short backing image read as
+// erased (0xFF).
+type Image struct {
+	backing io.ReaderAt
+	closer  io.Closer
+	overlay map[int]*[PageSize]byte
+}
+
+// NewImage returns an Image backed by r. If r is nil, the Image starts fully
+// erased.
+func NewImage(r io.ReaderAt) *Image {
+	return &Image{
+		backing: r,
+		overlay: map[int]*[PageSize]byte{},
+	}
+}
+
+// OpenImage opens the file at path read-only and returns an Image backed by
+// it. The file must not be larger than a whole AT45DB041.
+func OpenImage(path string) (*Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	finfo, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if finfo.Size() > Size {
+		f.Close()
+		return nil, fmt.Errorf("image %s is %d bytes, larger than the %d byte flash", path, finfo.Size(), Size)
+	}
+	im := NewImage(f)
+	im.closer = f
+	return im, nil
+}
+
+// Close releases the backing file, if the Image owns one. The overlay is
+// discarded.
+func (im *Image) Close() error {
+	im.overlay = map[int]*[PageSize]byte{}
+	if im.closer != nil {
+		return im.closer.Close()
+	}
+	return nil
+}
+
+// ReadPage copies page into buf, which must be at least PageSize bytes.
+func (im *Image) ReadPage(page int, buf []byte) error {
+	if err := checkPage(page); err != nil {
+		return err
+	}
+	if p, ok := im.overlay[page]; ok {
+		copy(buf, p[:])
+		return nil
+	}
+	for b := 0; b < PageSize; b++ {
+		buf[b] = 0xFF
+	}
+	if im.backing == nil {
+		return nil
+	}
+	n, err := im.backing.ReadAt(buf[:PageSize], int64(page*PageSize))
+	if err == io.EOF {
+		// A short image: whatever wasn't read is still erased, but ReadAt
+		// is allowed to have scribbled over it.
+		for b := n; b < PageSize; b++ {
+			buf[b] = 0xFF
+		}
+		return nil
+	}
+	return err
+}
+
+// WritePage replaces the contents of page with data, which must be at least
+// PageSize bytes. The write only ever lands in the overlay.
+func (im *Image) WritePage(page int, data []byte) error {
+	if err := checkPage(page); err != nil {
+		return err
+	}
+	if len(data) < PageSize {
+		return fmt.Errorf("page data is %d bytes, expected %d", len(data), PageSize)
+	}
+	p := &[PageSize]byte{}
+	copy(p[:], data)
+	im.overlay[page] = p
+	return nil
+}
+
+// DirtyPages returns, in ascending order, the pages that have been written
+// since the Image was opened or last Reset.
+func (im *Image) DirtyPages() []int {
+	pages := make([]int, 0, len(im.overlay))
+	for page := range im.overlay {
+		pages = append(pages, page)
+	}
+	sort.Ints(pages)
+	return pages
+}
+
+// Reset drops every write made to the Image, returning it to the contents of
+// the backing image.
+func (im *Image) Reset() {
+	im.overlay = map[int]*[PageSize]byte{}
+}
+
+// WriteTo writes the whole flash, including overlaid pages, to w. It can be
+// used to save the state of the flash to a new file.
+func (im *Image) WriteTo(w io.Writer) (int64, error) {
+	total := int64(0)
+	buf := make([]byte, PageSize)
+	for page := 0; page < Pages; page++ {
+		if err := im.ReadPage(page, buf); err != nil {
+			return total, err
+		}
+		n, err := w.Write(buf)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func checkPage(page int) error {
+	if page < 0 || page >= Pages {
+		return fmt.Errorf("page %d out of range, flash has %d pages", page, Pages)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package disassembler_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kn100/cybemu/disassembler"
+)
+
+// seedInstructions returns the instructions in the fuzzing seed corpus which
+// decode to exactly their own length.
+func seedInstructions(tb testing.TB) [][]byte {
+	tb.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", "fuzz", "FuzzDecode", "*"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	insts := [][]byte{}
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+		quoted := strings.TrimSuffix(strings.TrimPrefix(lines[len(lines)-1], "[]byte("), ")")
+		s, err := strconv.Unquote(quoted)
+		if err != nil {
+			tb.Fatalf("%s: %s", path, err)
+		}
+		if len(s) > 0 && disassembler.Decode([]byte(s)).TotalBytes == len(s) {
+			insts = append(insts, []byte(s))
+		}
+	}
+	return insts
+}
+
+// dump returns at least size bytes of code made up of the seed instructions.
+func dump(tb testing.TB, size int) []byte {
+	insts := seedInstructions(tb)
+	code := []byte{}
+	for len(code) < size {
+		for _, inst := range insts {
+			code = append(code, inst...)
+		}
+	}
+	return code
+}
+
+func TestDecodeDoesNotAllocate(t *testing.T) {
+	insts := seedInstructions(t)
+	allocs := testing.AllocsPerRun(10, func() {
+		for _, raw := range insts {
+			inst := disassembler.Decode(raw)
+			inst.Bytes = raw
+			inst.DetermineOperandTypeAndSetData()
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("decoding %d instructions allocated %v times", len(insts), allocs)
+	}
+	// Short input is padded on the stack.
+	allocs = testing.AllocsPerRun(10, func() {
+		disassembler.Decode([]byte{0x01})
+	})
+	if allocs != 0 {
+		t.Errorf("decoding short input allocated %v times", allocs)
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	insts := seedInstructions(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		raw := insts[n%len(insts)]
+		inst := disassembler.Decode(raw)
+		inst.Bytes = raw
+		inst.DetermineOperandTypeAndSetData()
+	}
+}
+
+func BenchmarkDisassemble(b *testing.B) {
+	code := dump(b, 1<<20)
+	b.SetBytes(int64(len(code)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		disassembler.Disassemble(code)
+	}
+}
@@ -19,13 +19,16 @@ func TestTable234(t *testing.T) {
 			ExpectedOpcode: opcode.Btst,
 		},
 		{
-			// FF FF FF FF FF FF FF FF
-			Input:          []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+			// 6A FF FF FF FF FF FF FF
+			Input:          []byte{0x6A, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
 			ExpectedOpcode: opcode.Invalid,
 		},
 	}
 	for _, tc := range testCases {
-		inst := table234(tc.Input)
+		inst := Decode(tc.Input)
 		assert.Equal(t, tc.ExpectedOpcode, inst.Opcode)
+		if tc.ExpectedOpcode == opcode.Invalid {
+			assert.Equal(t, 2, inst.TotalBytes)
+		}
 	}
 }
@@ -13,6 +13,12 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// withOperands returns inst with its operands set to ops.
+func withOperands(inst instruction.Inst, ops ...instruction.Operand) instruction.Inst {
+	inst.SetOperands(ops...)
+	return inst
+}
+
 // TestDisassembleTimsTestCases Tests the disassembler against Tim's test cases.
 // It's a useful test to determine every possible instruction decodes correctly.
 func TestDisassemble(t *testing.T) {
@@ -34,36 +40,45 @@ func TestDisassemble(t *testing.T) {
 				0x01, 0x00, 0x78, 0x70, 0x6B, 0x23, 0x00, 0x00, 0x27, 0x0E,
 			},
 			ExpectedInsts: []instruction.Inst{
-				{
-					Opcode:         opcode.Add,
-					Bytes:          []byte{0x8D, 0x81},
-					TotalBytes:     2,
-					BWL:            size.Byte,
-					AddressingMode: addressingmode.Immediate,
-					OperandType:    operand.I8_R8,
-					RegDst:         []byte{0x0D},
-					Imm:            []byte{0x81},
-					Pos:            0,
-				},
-				{
-					Opcode:         opcode.And,
-					Bytes:          []byte{0x79, 0x6C, 0x26, 0x94},
-					TotalBytes:     4,
-					BWL:            size.Word,
-					OperandType:    operand.I16_R16,
-					AddressingMode: addressingmode.Immediate,
-					RegDst:         []byte{0x0C},
-					Imm:            []byte{0x26, 0x94},
-					Pos:            2,
-				},
-				{
-					Opcode:         opcode.Mov,
-					Bytes:          []byte{0x01, 0x00, 0x78, 0x70, 0x6B, 0x23, 0x00, 0x00, 0x27, 0x0E},
-					TotalBytes:     10,
-					BWL:            size.Longword,
-					AddressingMode: addressingmode.RegisterIndirectWithDisplacement,
-					Pos:            6,
-				},
+				withOperands(
+					instruction.Inst{
+						Opcode:         opcode.Add,
+						Bytes:          []byte{0x8D, 0x81},
+						TotalBytes:     2,
+						BWL:            size.Byte,
+						AddressingMode: addressingmode.Immediate,
+						OperandType:    operand.I8_R8,
+						Pos:            0,
+					},
+					instruction.Immediate{Value: 0x81, Size: size.Byte}.Operand(),
+					instruction.Register{Number: 0x0D, Size: size.Byte}.Operand(),
+				),
+				withOperands(
+					instruction.Inst{
+						Opcode:         opcode.And,
+						Bytes:          []byte{0x79, 0x6C, 0x26, 0x94},
+						TotalBytes:     4,
+						BWL:            size.Word,
+						OperandType:    operand.I16_R16,
+						AddressingMode: addressingmode.Immediate,
+						Pos:            2,
+					},
+					instruction.Immediate{Value: 0x2694, Size: size.Word}.Operand(),
+					instruction.Register{Number: 0x0C, Size: size.Word}.Operand(),
+				),
+				withOperands(
+					instruction.Inst{
+						Opcode:         opcode.Mov,
+						Bytes:          []byte{0x01, 0x00, 0x78, 0x70, 0x6B, 0x23, 0x00, 0x00, 0x27, 0x0E},
+						TotalBytes:     10,
+						BWL:            size.Longword,
+						AddressingMode: addressingmode.RegisterIndirectWithDisplacement,
+						OperandType:    operand.AI32R32_R32,
+						Pos:            6,
+					},
+					instruction.Displacement{Register: 7, Displacement: 0x270E, Width: 32}.Operand(),
+					instruction.Register{Number: 3, Size: size.Longword}.Operand(),
+				),
 			},
 		},
 	}
@@ -3252,3 +3267,26 @@ func TestDecodeRangeCases(t *testing.T) {
 		}
 	}
 }
+
+func TestDisassembleAt(t *testing.T) {
+	insts := disassembler.DisassembleAt([]byte{0x00, 0x00, 0x40, 0xFC}, 0x100)
+	assert.Equal(t, 2, len(insts))
+	assert.Equal(t, 0x100, insts[0].Pos)
+	assert.Equal(t, 0x102, insts[1].Pos)
+	assert.Equal(t, "bra 0x00000100:8", insts[1].String())
+}
+
+func TestShortInput(t *testing.T) {
+	assert.Equal(t, opcode.Nop, disassembler.Decode([]byte{}).Opcode)
+	// A mov.l with a 32 bit displacement, missing its last 2 bytes.
+	inst := disassembler.Decode([]byte{0x01, 0x00, 0x78, 0x70, 0x6B, 0x23, 0x00, 0x00})
+	assert.Equal(t, opcode.Mov, inst.Opcode)
+	assert.Equal(t, 10, inst.TotalBytes)
+
+	insts := disassembler.DisassembleAt([]byte{0x00, 0x00, 0x01, 0x00, 0x78, 0x70, 0x6B, 0x23, 0x00}, 0x100)
+	assert.Equal(t, 2, len(insts))
+	assert.Equal(t, opcode.Invalid, insts[1].Opcode)
+	assert.Equal(t, 0x102, insts[1].Pos)
+	assert.Equal(t, 7, insts[1].TotalBytes)
+	assert.Equal(t, []byte{0x01, 0x00, 0x78, 0x70, 0x6B, 0x23, 0x00}, insts[1].Bytes)
+}
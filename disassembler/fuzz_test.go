@@ -0,0 +1,49 @@
+package disassembler_test
+
+import (
+	"testing"
+
+	"github.com/kn100/cybemu/disassembler"
+)
+
+// FuzzDecode feeds arbitrary bytes through Decode, DetermineOperandTypeAndSetData
+// and String, the way DisassembleAt does. The seed corpus in testdata/fuzz holds
+// the instructions from the disassembler and instruction tests.
+func FuzzDecode(f *testing.F) {
+	f.Fuzz(func(t *testing.T, bytes []byte) {
+		inst := disassembler.Decode(bytes)
+		if inst.TotalBytes < 2 || inst.TotalBytes > 10 || inst.TotalBytes%2 != 0 {
+			t.Fatalf("% x decoded to %d bytes", bytes, inst.TotalBytes)
+		}
+		// Decode treats missing bytes as zeroes, so operand extraction has to
+		// see them too.
+		raw := make([]byte, inst.TotalBytes)
+		copy(raw, bytes)
+		inst.Bytes = raw
+		inst.DetermineOperandTypeAndSetData()
+		if len(inst.Bytes) != inst.TotalBytes {
+			t.Fatalf("% x has %d bytes after operand extraction, but TotalBytes is %d", bytes, len(inst.Bytes), inst.TotalBytes)
+		}
+		_ = inst.String()
+	})
+}
+
+// FuzzDisassemble checks that DisassembleAt accounts for every byte it is
+// given, in order, however the input ends.
+func FuzzDisassemble(f *testing.F) {
+	f.Fuzz(func(t *testing.T, bytes []byte) {
+		next := 0x1000
+		for _, inst := range disassembler.DisassembleAt(bytes, 0x1000) {
+			if inst.Pos != next {
+				t.Fatalf("% x: instruction at 0x%X, expected 0x%X", bytes, inst.Pos, next)
+			}
+			if len(inst.Bytes) != inst.TotalBytes {
+				t.Fatalf("% x: instruction at 0x%X has %d bytes, but TotalBytes is %d", bytes, inst.Pos, len(inst.Bytes), inst.TotalBytes)
+			}
+			next += inst.TotalBytes
+		}
+		if next != 0x1000+len(bytes) {
+			t.Fatalf("% x: disassembled %d bytes", bytes, next-0x1000)
+		}
+	})
+}
@@ -0,0 +1,433 @@
+// contains a stub speaking GDB's remote serial protocol, so that
+// h8300-elf-gdb can attach to code running in the emulator. It follows the
+// "Remote Protocol" appendix of the GDB manual, and implements the packets
+// needed to inspect and control a target: ? g G p P m M c s Z0 z0, along with
+// qSupported. Registers are laid out as h8300-elf-gdb expects for the h8300s.
+package gdbstub
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/kn100/cybemu/machine"
+)
+
+// Signals reported to the debugger when the target stops.
+const (
+	sigint  = 0x02
+	sigill  = 0x04
+	sigtrap = 0x05
+)
+
+// interrupt is the byte GDB sends, outside of a packet, to stop a running
+// target.
+const interrupt = 0x03
+
+// errDetached is returned internally when the debugger asks to end the
+// session.
+var errDetached = errors.New("debugger detached")
+
+// errBadChecksum is returned by readPacket when a packet was damaged on the
+// way, and should be sent again.
+var errBadChecksum = errors.New("bad checksum")
+
+// Server serves the remote serial protocol for a machine.Target.
+type Server struct {
+	target      machine.Target
+	breakpoints map[uint32]bool
+	noAck       bool
+}
+
+// NewServer returns a Server debugging target.
+func NewServer(target machine.Target) *Server {
+	return &Server{
+		target:      target,
+		breakpoints: map[uint32]bool{},
+	}
+}
+
+// ListenAndServe listens on the TCP address addr, and serves debuggers which
+// connect to it one at a time.
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		err = s.Serve(conn)
+		conn.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// event is something received from the debugger: either a whole packet, a
+// damaged one, or an interrupt.
+type event struct {
+	packet      string
+	badChecksum bool
+	interrupt   bool
+	err         error
+}
+
+// Serve runs a single debugging session over conn, returning when the
+// debugger detaches, kills the target or disconnects. Breakpoints are cleared
+// at the start of every session.
+func (s *Server) Serve(conn io.ReadWriter) error {
+	s.breakpoints = map[uint32]bool{}
+	s.noAck = false
+	events := make(chan event)
+	done := make(chan struct{})
+	defer close(done)
+	go readEvents(bufio.NewReader(conn), events, done)
+
+	for ev := range events {
+		if ev.err == io.EOF {
+			return nil
+		} else if ev.err != nil {
+			return ev.err
+		}
+		if ev.interrupt {
+			// The target is already stopped.
+			continue
+		}
+		if ev.badChecksum {
+			// Ask for the packet again.
+			if !s.noAck {
+				if _, err := conn.Write([]byte{'-'}); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !s.noAck {
+			if _, err := conn.Write([]byte{'+'}); err != nil {
+				return err
+			}
+		}
+		reply, err := s.handle(ev.packet, events)
+		if err == errDetached {
+			if reply != "" {
+				writePacket(conn, reply)
+			}
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := writePacket(conn, reply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readEvents parses the bytes sent by the debugger into events, until it hits
+// an error or done is closed.
+func readEvents(r *bufio.Reader, events chan<- event, done <-chan struct{}) {
+	defer close(events)
+	send := func(ev event) bool {
+		select {
+		case events <- ev:
+			return true
+		case <-done:
+			return false
+		}
+	}
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			send(event{err: err})
+			return
+		}
+		switch b {
+		case interrupt:
+			if !send(event{interrupt: true}) {
+				return
+			}
+		case '$':
+			packet, err := readPacket(r)
+			if errors.Is(err, errBadChecksum) {
+				if !send(event{badChecksum: true}) {
+					return
+				}
+				continue
+			} else if err != nil {
+				send(event{err: err})
+				return
+			}
+			if !send(event{packet: packet}) {
+				return
+			}
+		}
+		// Anything else, such as acks, is ignored.
+	}
+}
+
+// readPacket reads the rest of a packet once its leading $ has been read.
+func readPacket(r *bufio.Reader) (string, error) {
+	var data strings.Builder
+	sum := byte(0)
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		if b == '#' {
+			break
+		}
+		sum += b
+		if b == '}' {
+			e, err := r.ReadByte()
+			if err != nil {
+				return "", err
+			}
+			sum += e
+			b = e ^ 0x20
+		}
+		data.WriteByte(b)
+	}
+	checksum := make([]byte, 2)
+	if _, err := io.ReadFull(r, checksum); err != nil {
+		return "", err
+	}
+	want, err := strconv.ParseUint(string(checksum), 16, 8)
+	if err != nil || byte(want) != sum {
+		return "", fmt.Errorf("%w on packet %q", errBadChecksum, data.String())
+	}
+	return data.String(), nil
+}
+
+// writePacket frames data as a packet and writes it to w.
+func writePacket(w io.Writer, data string) error {
+	sum := byte(0)
+	for i := 0; i < len(data); i++ {
+		sum += data[i]
+	}
+	_, err := fmt.Fprintf(w, "$%s#%02x", data, sum)
+	return err
+}
+
+// handle executes a single packet, and returns the reply to send.
+func (s *Server) handle(packet string, events <-chan event) (string, error) {
+	if packet == "" {
+		return "", nil
+	}
+	args := packet[1:]
+	switch packet[0] {
+	case '?':
+		return stopReply(sigtrap), nil
+	case 'g':
+		return hex.EncodeToString(encodeRegisters(s.target.Registers())), nil
+	case 'G':
+		b, err := hex.DecodeString(args)
+		if err != nil || len(b) != registersSize {
+			return "E01", nil
+		}
+		s.target.SetRegisters(decodeRegisters(b))
+		return "OK", nil
+	case 'p':
+		n, err := strconv.ParseUint(args, 16, 8)
+		if err != nil || int(n) >= len(registerLayout) {
+			return "E01", nil
+		}
+		b := encodeRegisters(s.target.Registers())
+		off, size := registerOffset(int(n))
+		return hex.EncodeToString(b[off : off+size]), nil
+	case 'P':
+		eq := strings.IndexByte(args, '=')
+		if eq < 0 {
+			return "E01", nil
+		}
+		n, err := strconv.ParseUint(args[:eq], 16, 8)
+		if err != nil || int(n) >= len(registerLayout) {
+			return "E01", nil
+		}
+		off, size := registerOffset(int(n))
+		v, err := hex.DecodeString(args[eq+1:])
+		if err != nil || len(v) != size {
+			return "E01", nil
+		}
+		b := encodeRegisters(s.target.Registers())
+		copy(b[off:], v)
+		s.target.SetRegisters(decodeRegisters(b))
+		return "OK", nil
+	case 'm':
+		addr, length, err := parseAddrLength(args)
+		if err != nil {
+			return "E01", nil
+		}
+		buf := make([]byte, length)
+		if err := s.target.ReadMemory(addr, buf); err != nil {
+			return "E02", nil
+		}
+		return hex.EncodeToString(buf), nil
+	case 'M':
+		colon := strings.IndexByte(args, ':')
+		if colon < 0 {
+			return "E01", nil
+		}
+		addr, length, err := parseAddrLength(args[:colon])
+		if err != nil {
+			return "E01", nil
+		}
+		data, err := hex.DecodeString(args[colon+1:])
+		if err != nil || len(data) != length {
+			return "E01", nil
+		}
+		if err := s.target.WriteMemory(addr, data); err != nil {
+			return "E02", nil
+		}
+		return "OK", nil
+	case 's':
+		if err := s.resumeAt(args); err != nil {
+			return "E01", nil
+		}
+		if err := s.target.Step(); err != nil {
+			return stopReply(sigill), nil
+		}
+		return stopReply(sigtrap), nil
+	case 'c':
+		if err := s.resumeAt(args); err != nil {
+			return "E01", nil
+		}
+		return s.cont(events)
+	case 'Z', 'z':
+		kind, addr, err := parseBreakpoint(args)
+		if err != nil {
+			return "E01", nil
+		}
+		if kind != 0 {
+			// Only software breakpoints are supported.
+			return "", nil
+		}
+		if packet[0] == 'Z' {
+			s.breakpoints[addr] = true
+		} else {
+			delete(s.breakpoints, addr)
+		}
+		return "OK", nil
+	case 'H':
+		// There's only one thread.
+		return "OK", nil
+	case 'D':
+		return "OK", errDetached
+	case 'k':
+		return "", errDetached
+	case 'q':
+		return s.query(args), nil
+	case 'Q':
+		if args == "StartNoAckMode" {
+			s.noAck = true
+			return "OK", nil
+		}
+	}
+	// An empty reply tells the debugger the packet isn't supported.
+	return "", nil
+}
+
+// query answers the general query packets.
+func (s *Server) query(args string) string {
+	switch {
+	case strings.HasPrefix(args, "Supported"):
+		return "PacketSize=4000;QStartNoAckMode+"
+	case args == "Attached":
+		return "1"
+	case args == "C":
+		return "QC1"
+	case args == "fThreadInfo":
+		return "m1"
+	case args == "sThreadInfo":
+		return "l"
+	}
+	return ""
+}
+
+// resumeAt handles the optional address c and s packets take, which moves PC
+// before resuming.
+func (s *Server) resumeAt(args string) error {
+	if args == "" {
+		return nil
+	}
+	addr, err := strconv.ParseUint(args, 16, 32)
+	if err != nil {
+		return err
+	}
+	regs := s.target.Registers()
+	regs.PC = uint32(addr)
+	s.target.SetRegisters(regs)
+	return nil
+}
+
+// cont runs the target until it reaches a breakpoint, fails to execute an
+// instruction, or the debugger interrupts it.
+func (s *Server) cont(events <-chan event) (string, error) {
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok || ev.err != nil {
+				return "", errDetached
+			}
+			if ev.interrupt {
+				return stopReply(sigint), nil
+			}
+			// Nothing but an interrupt should arrive while running.
+		default:
+		}
+		if err := s.target.Step(); err != nil {
+			return stopReply(sigill), nil
+		}
+		if s.breakpoints[s.target.Registers().PC] {
+			return stopReply(sigtrap), nil
+		}
+	}
+}
+
+func stopReply(signal int) string {
+	return fmt.Sprintf("S%02x", signal)
+}
+
+// parseAddrLength parses the addr,length argument of m and M packets.
+func parseAddrLength(args string) (uint32, int, error) {
+	parts := strings.Split(args, ",")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("expected addr,length, got %q", args)
+	}
+	addr, err := strconv.ParseUint(parts[0], 16, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	length, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint32(addr), int(length), nil
+}
+
+// parseBreakpoint parses the type,addr,kind argument of Z and z packets.
+func parseBreakpoint(args string) (int, uint32, error) {
+	parts := strings.Split(args, ",")
+	if len(parts) != 3 {
+		return 0, 0, fmt.Errorf("expected type,addr,kind, got %q", args)
+	}
+	kind, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	addr, err := strconv.ParseUint(parts[1], 16, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	return kind, uint32(addr), nil
+}
@@ -0,0 +1,213 @@
+package gdbstub_test
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/kn100/cybemu/cybemutest"
+	"github.com/kn100/cybemu/gdbstub"
+	"github.com/kn100/cybemu/machine"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTarget returns a machine with 0x1000 bytes of memory, holding nothing but
+// nops.
+func newTarget() *cybemutest.Machine {
+	return cybemutest.NewMachine(0x1000)
+}
+
+// client is a minimal debugger speaking the remote serial protocol.
+type client struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func newSession(t *testing.T, target machine.Target) (*client, chan error) {
+	stub, debugger := net.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- gdbstub.NewServer(target).Serve(stub)
+		stub.Close()
+	}()
+	return &client{t: t, conn: debugger, r: bufio.NewReader(debugger)}, done
+}
+
+// send sends a packet, and returns the reply to it.
+func (c *client) send(data string) string {
+	c.sendNoReply(data)
+	return c.reply()
+}
+
+// sendNoReply sends a packet which won't be replied to, such as k.
+func (c *client) sendNoReply(data string) {
+	sum := byte(0)
+	for i := 0; i < len(data); i++ {
+		sum += data[i]
+	}
+	_, err := fmt.Fprintf(c.conn, "$%s#%02x", data, sum)
+	assert.NoError(c.t, err)
+	ack, err := c.r.ReadByte()
+	assert.NoError(c.t, err)
+	assert.Equal(c.t, byte('+'), ack, "packet %q wasn't acked", data)
+}
+
+func (c *client) reply() string {
+	start, err := c.r.ReadByte()
+	assert.NoError(c.t, err)
+	assert.Equal(c.t, byte('$'), start)
+	reply, err := c.r.ReadString('#')
+	assert.NoError(c.t, err)
+	checksum := make([]byte, 2)
+	_, err = c.r.Read(checksum)
+	assert.NoError(c.t, err)
+	reply = strings.TrimSuffix(reply, "#")
+	sum := byte(0)
+	for i := 0; i < len(reply); i++ {
+		sum += reply[i]
+	}
+	assert.Equal(c.t, fmt.Sprintf("%02x", sum), string(checksum))
+	return reply
+}
+
+// gdbRegisters returns a g packet laid out as h8300-elf-gdb lays out the
+// h8300s registers, by regnum: er0-er6, sp, ccr, pc, cycles, exr, tick, inst,
+// mach and macl, each a big endian longword.
+func gdbRegisters(regs ...uint32) string {
+	s := ""
+	for _, r := range regs {
+		s += fmt.Sprintf("%08x", r)
+	}
+	return s
+}
+
+func TestRegisters(t *testing.T) {
+	target := newTarget()
+	target.Regs.ER = [8]uint32{0, 1, 2, 3, 4, 5, 6, 0xFFFF00}
+	target.Regs.PC = 0x100
+	target.Regs.CCR = 0x80
+	target.Regs.EXR = 0x07
+	c, done := newSession(t, target)
+
+	assert.Equal(t, "S05", c.send("?"))
+	regs := gdbRegisters(0, 1, 2, 3, 4, 5, 6, 0xFFFF00, 0x80, 0x100, 0, 0x07, 0, 0, 0, 0)
+	assert.Equal(t, regs, c.send("g"))
+	assert.Equal(t, "00000100", c.send("p9"))
+	assert.Equal(t, "00000007", c.send("pb"), "exr is regnum 11")
+	assert.Equal(t, "00000000", c.send("pa"), "cycles isn't kept")
+	assert.Equal(t, "E01", c.send("p10"))
+	assert.Equal(t, "E01", c.send("pff"))
+
+	// GDB always writes every register, including the ones the target
+	// doesn't have, which are ignored.
+	regs = gdbRegisters(9, 1, 2, 3, 4, 5, 6, 0xFFFF00, 0x84, 0x200, 0x1234, 0x03, 1, 2, 3, 4)
+	assert.Equal(t, "OK", c.send("G"+regs))
+	assert.Equal(t, uint32(9), target.Regs.ER[0])
+	assert.Equal(t, uint32(0x200), target.Regs.PC)
+	assert.Equal(t, byte(0x84), target.Regs.CCR)
+	assert.Equal(t, byte(0x03), target.Regs.EXR)
+	assert.Equal(t, "E01", c.send("G00"))
+	assert.Equal(t, "E01", c.send("G"+regs[:11*8]), "a packet without cycles onwards is too short")
+
+	assert.Equal(t, "OK", c.send("P9=00000300"))
+	assert.Equal(t, uint32(0x300), target.Regs.PC)
+	assert.Equal(t, "OK", c.send("Pe=12345678"))
+	assert.Equal(t, "E01", c.send("P9=0300"))
+	assert.Equal(t, "E01", c.send("P10=00000000"))
+
+	assert.Equal(t, "OK", c.send("D"))
+	assert.NoError(t, <-done)
+}
+
+func TestMemory(t *testing.T) {
+	target := newTarget()
+	c, done := newSession(t, target)
+
+	assert.Equal(t, "OK", c.send("M10,3:0a0b0c"))
+	assert.Equal(t, []byte{0x0A, 0x0B, 0x0C}, target.Memory[0x10:0x13])
+	assert.Equal(t, "000a0b0c00", c.send("mf,5"))
+	assert.Equal(t, "E02", c.send("mfff,2"))
+	assert.Equal(t, "E01", c.send("M10,2:0a"))
+
+	c.conn.Close()
+	assert.NoError(t, <-done)
+}
+
+func TestStepAndContinue(t *testing.T) {
+	target := newTarget()
+	target.Memory[0x20], target.Memory[0x21] = 0xFF, 0xFF
+	c, done := newSession(t, target)
+
+	assert.Equal(t, "S05", c.send("s"))
+	assert.Equal(t, uint32(2), target.Regs.PC)
+
+	assert.Equal(t, "OK", c.send("Z0,a,2"))
+	assert.Equal(t, "S05", c.send("c"))
+	assert.Equal(t, uint32(0xA), target.Regs.PC)
+
+	// Continuing from a breakpoint doesn't stop on it again.
+	assert.Equal(t, "OK", c.send("z0,a,2"))
+	assert.Equal(t, "S04", c.send("c"))
+	assert.Equal(t, uint32(0x20), target.Regs.PC)
+
+	assert.Equal(t, "S05", c.send("s40"))
+	assert.Equal(t, uint32(0x42), target.Regs.PC)
+	assert.Equal(t, "", c.send("Z1,a,2"), "hardware breakpoints aren't supported")
+
+	c.sendNoReply("k")
+	assert.NoError(t, <-done)
+}
+
+func TestInterrupt(t *testing.T) {
+	// A target with no invalid instructions runs until it's interrupted.
+	stepper := &wrappingTarget{newTarget()}
+	c, done := newSession(t, stepper)
+
+	c.sendNoReply("c")
+	_, err := c.conn.Write([]byte{0x03})
+	assert.NoError(t, err)
+	assert.Equal(t, "S02", c.reply())
+
+	c.sendNoReply("k")
+	assert.NoError(t, <-done)
+}
+
+// wrappingTarget keeps PC inside the machine's memory.
+type wrappingTarget struct {
+	*cybemutest.Machine
+}
+
+func (w *wrappingTarget) Step() error {
+	err := w.Machine.Step()
+	w.Regs.PC %= uint32(len(w.Memory))
+	return err
+}
+
+func TestBadChecksum(t *testing.T) {
+	c, done := newSession(t, newTarget())
+
+	_, err := fmt.Fprint(c.conn, "$?#00")
+	assert.NoError(t, err)
+	nak, err := c.r.ReadByte()
+	assert.NoError(t, err)
+	assert.Equal(t, byte('-'), nak)
+	// The session carries on, so the packet can be sent again.
+	assert.Equal(t, "S05", c.send("?"))
+
+	c.sendNoReply("k")
+	assert.NoError(t, <-done)
+}
+
+func TestQueries(t *testing.T) {
+	c, done := newSession(t, newTarget())
+
+	assert.Equal(t, "PacketSize=4000;QStartNoAckMode+", c.send("qSupported:multiprocess+;xmlRegisters=i386"))
+	assert.Equal(t, "", c.send("qXfer:features:read:target.xml:0,100"), "GDB ignores target descriptions for the H8")
+	assert.Equal(t, "", c.send("vMustReplyEmpty"))
+
+	c.send("D")
+	assert.NoError(t, <-done)
+}
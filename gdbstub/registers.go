@@ -0,0 +1,58 @@
+package gdbstub
+
+import (
+	"encoding/binary"
+
+	"github.com/kn100/cybemu/machine"
+)
+
+// registerLayout is the order registers appear in the g and G packets, which
+// is the one h8300-elf-gdb uses for the h8300s: the regnums in its
+// h8300-tdep.c. GDB ignores target descriptions for the H8, so the layout
+// can't be changed. Every register is sent as a big endian longword, with CCR
+// and EXR in the least significant byte.
+var registerLayout = []string{
+	"er0", "er1", "er2", "er3", "er4", "er5", "er6", "sp",
+	"ccr", "pc", "cycles", "exr", "tick", "inst", "mach", "macl",
+}
+
+// Regnums of the registers the target has. cycles, tick and inst are the
+// simulator's counters, and mach and macl belong to the multiplier found on
+// the H8S/2600, so they read as zero and writes to them are ignored.
+const (
+	regCCR = 8
+	regPC  = 9
+	regEXR = 11
+)
+
+const registerBytes = 4
+
+var registersSize = len(registerLayout) * registerBytes
+
+// registerOffset returns where register n starts in the g packet, and how
+// many bytes it takes.
+func registerOffset(n int) (int, int) {
+	return n * registerBytes, registerBytes
+}
+
+func encodeRegisters(regs machine.Registers) []byte {
+	b := make([]byte, registersSize)
+	for n, er := range regs.ER {
+		binary.BigEndian.PutUint32(b[n*registerBytes:], er)
+	}
+	binary.BigEndian.PutUint32(b[regCCR*registerBytes:], uint32(regs.CCR))
+	binary.BigEndian.PutUint32(b[regPC*registerBytes:], regs.PC)
+	binary.BigEndian.PutUint32(b[regEXR*registerBytes:], uint32(regs.EXR))
+	return b
+}
+
+func decodeRegisters(b []byte) machine.Registers {
+	regs := machine.Registers{}
+	for n := range regs.ER {
+		regs.ER[n] = binary.BigEndian.Uint32(b[n*registerBytes:])
+	}
+	regs.CCR = byte(binary.BigEndian.Uint32(b[regCCR*registerBytes:]))
+	regs.PC = binary.BigEndian.Uint32(b[regPC*registerBytes:])
+	regs.EXR = byte(binary.BigEndian.Uint32(b[regEXR*registerBytes:]))
+	return regs
+}
@@ -0,0 +1,207 @@
+package instruction
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kn100/cybemu/addressingmode"
+	"github.com/kn100/cybemu/opcode"
+	"github.com/kn100/cybemu/operand"
+	"github.com/kn100/cybemu/size"
+)
+
+//go:generate go run ./gen
+
+// form is one line of the instruction table in instructions.txt: an encoding,
+// and the instruction it decodes to.
+type form struct {
+	// An instruction matches the form when its first 8 bytes, masked with
+	// mask, are value.
+	mask, value uint64
+	length      int
+	// complete is set when every bit of the encoding is either fixed, zero or
+	// in a field, so that Encode can produce it.
+	complete    bool
+	opcode      opcode.Opcode
+	bwl         size.Size
+	mode        addressingmode.AddressingMode
+	operandSize int
+	operandType operand.OperandType
+	fields      []field
+}
+
+// role says what a field holds, which layouts use to make operands from it.
+type role uint8
+
+const (
+	regDst role = iota
+	regSrc
+	reg
+	imm
+	immL
+	immR
+	ctl
+)
+
+// field is an operand field of a form. It is width bits starting start bits
+// from the top of the first byte, or if width is 0, the constant value.
+type field struct {
+	role  role
+	start uint8
+	width uint8
+	value byte
+}
+
+// ErrNoEncoding is returned by Encode when there is no encoding for an
+// instruction.
+var ErrNoEncoding = errors.New("no encoding")
+
+// Decode returns the instruction which bytes starts with. Missing bytes are
+// decoded as if they were zeroes. Bytes and Pos are left for the caller to set,
+// and operands are left for DetermineOperandTypeAndSetData. If bytes doesn't
+// start with a valid instruction, the instruction returned is an invalid one
+// of 2 bytes.
+func Decode(bytes []byte) Inst {
+	n := lookup(first8(bytes))
+	if n < 0 {
+		return Inst{Opcode: opcode.Invalid, TotalBytes: 2}
+	}
+	f := &forms[n]
+	return Inst{
+		TotalBytes:     f.length,
+		BWL:            f.bwl,
+		Opcode:         f.opcode,
+		AddressingMode: f.mode,
+		OperandSize:    f.operandSize,
+	}
+}
+
+// first8 returns the first 8 bytes of b, big endian, padded with zeroes.
+func first8(b []byte) uint64 {
+	var w uint64
+	for n := 0; n < 8; n++ {
+		w <<= 8
+		if n < len(b) {
+			w |= uint64(b[n])
+		}
+	}
+	return w
+}
+
+// form returns the form which Bytes matches, or nil if there isn't one or it
+// doesn't have the Opcode, BWL and AddressingMode of i.
+func (i *Inst) form() *form {
+	w := first8(i.Bytes)
+	if n := lookup(w); n >= 0 && forms[n].is(i) {
+		return &forms[n]
+	}
+	return nil
+}
+
+// is returns whether i could have form f.
+func (f *form) is(i *Inst) bool {
+	return f.opcode == i.Opcode && f.bwl == i.BWL && f.mode == i.AddressingMode && f.length == len(i.Bytes)
+}
+
+// get returns the value of the field of f with role r in b, or 0 if f has no
+// such field.
+func (f *form) get(r role, b []byte) uint32 {
+	for _, fl := range f.fields {
+		if fl.role == r {
+			return fl.extract(b)
+		}
+	}
+	return 0
+}
+
+// set puts v into the field of f with role r in b, returning false if it
+// doesn't fit. If f has no such field, v must be 0.
+func (f *form) set(r role, b []byte, v uint32) bool {
+	for _, fl := range f.fields {
+		if fl.role == r {
+			return fl.insert(b, v)
+		}
+	}
+	return v == 0
+}
+
+// extract returns the value of fl in b.
+func (fl field) extract(b []byte) uint32 {
+	if fl.width == 0 {
+		return uint32(fl.value)
+	}
+	if fl.width > 8 {
+		var v uint32
+		for _, c := range b[fl.start/8 : (fl.start+fl.width)/8] {
+			v = v<<8 | uint32(c)
+		}
+		return v
+	}
+	shift := 8 - fl.start%8 - fl.width
+	return uint32(b[fl.start/8] >> shift & (1<<fl.width - 1))
+}
+
+// insert puts v into fl in b, returning false if it doesn't fit.
+func (fl field) insert(b []byte, v uint32) bool {
+	if fl.width == 0 {
+		return v == uint32(fl.value)
+	}
+	if fl.width < 32 && v >= 1<<fl.width {
+		return false
+	}
+	if fl.width > 8 {
+		for n := int(fl.start+fl.width)/8 - 1; n >= int(fl.start/8); n-- {
+			b[n] = byte(v)
+			v >>= 8
+		}
+		return true
+	}
+	shift := 8 - fl.start%8 - fl.width
+	mask := byte(1<<fl.width-1) << shift
+	b[fl.start/8] = b[fl.start/8]&^mask | byte(v)<<shift
+	return true
+}
+
+// Encode returns the encoding of i, which is found from its Opcode, BWL,
+// AddressingMode, OperandType and Operands.
+func Encode(i Inst) ([]byte, error) {
+	for n := range forms {
+		f := &forms[n]
+		if !f.complete || f.opcode != i.Opcode || f.bwl != i.BWL || f.mode != i.AddressingMode || f.operandType != i.OperandType {
+			continue
+		}
+		if b, ok := f.encode(&i); ok {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("%w for %s%s with operand type %d", ErrNoEncoding, i.Opcode, size.GetSizeAsSuffix(i.BWL), i.OperandType)
+}
+
+// encode returns the encoding of i in f, or false if its operands don't fit.
+func (f *form) encode(i *Inst) ([]byte, bool) {
+	layout, ok := layouts[f.operandType]
+	ops := i.Operands()
+	if !ok || len(layout) != len(ops) {
+		return nil, false
+	}
+	b := make([]byte, f.length)
+	for n, s := range layout {
+		v, d, ok := s.value(ops[n], i)
+		if !ok || !f.set(s.role, b, v) {
+			return nil, false
+		}
+		if (s.kind == displacement || s.kind == registerList) && !f.set(imm, b, d) {
+			return nil, false
+		}
+	}
+	// Fields can overlap fixed bits, such as the top bit of a register
+	// field which is part of the opcode, so the fixed bits are added
+	// afterwards, and then checked in case a field clashed with them.
+	for n := 0; n < len(b) && n < 8; n++ {
+		b[n] |= byte(f.value >> (56 - 8*n))
+	}
+	if first8(b)&f.mask != f.value {
+		return nil, false
+	}
+	return b, true
+}
@@ -0,0 +1,102 @@
+package instruction_test
+
+import (
+	"testing"
+
+	"github.com/kn100/cybemu/addressingmode"
+	"github.com/kn100/cybemu/instruction"
+	"github.com/kn100/cybemu/opcode"
+	"github.com/kn100/cybemu/operand"
+	"github.com/kn100/cybemu/size"
+	"github.com/stretchr/testify/assert"
+)
+
+// decode decodes and sets the operands of the instruction bytes starts with.
+func decode(bytes []byte) instruction.Inst {
+	inst := instruction.Decode(bytes)
+	if inst.Opcode != opcode.Invalid {
+		inst.Bytes = bytes[:inst.TotalBytes]
+		inst.DetermineOperandTypeAndSetData()
+	}
+	return inst
+}
+
+func TestEncode(t *testing.T) {
+	testCases := []struct {
+		input    []byte
+		expected string
+	}{
+		{input: []byte{0x00, 0x00}, expected: "nop"},
+		{input: []byte{0x08, 0x3E}, expected: "add.b r3h, r6l"},
+		{input: []byte{0x0F, 0x92}, expected: "mov.l er1, er2"},
+		{input: []byte{0x1D, 0x12}, expected: "cmp.w r1, r2"},
+		{input: []byte{0x79, 0x02, 0x12, 0x34}, expected: "mov.w #0x1234, r2"},
+		{input: []byte{0x7A, 0x03, 0x00, 0x12, 0x34, 0x56}, expected: "mov.l #0x00123456, er3"},
+		{input: []byte{0x01, 0x00, 0x69, 0x93}, expected: "mov.l er3, @er1"},
+		{input: []byte{0x0B, 0x95}, expected: "adds #4, er5"},
+		{input: []byte{0x7D, 0x40, 0x72, 0x30}, expected: "bclr #3, @er4"},
+		{input: []byte{0x6A, 0x10, 0x12, 0x34, 0x74, 0xC0}, expected: "bior #4, @0x1234:16"},
+		{input: []byte{0x03, 0x1D}, expected: "ldc.b r5l, exr"},
+		{input: []byte{0x01, 0x41, 0x6B, 0x80, 0x12, 0x34}, expected: "stc.w exr, @0x1234:16"},
+		{input: []byte{0x6E, 0x1A, 0xFF, 0xFC}, expected: "mov.b @(0xFFFC:16, er1), r2l"},
+		{input: []byte{0x01, 0x00, 0x78, 0x70, 0x6B, 0x23, 0x00, 0x00, 0x27, 0x0E}, expected: "mov.l @(0x0000270E:32, sp), er3"},
+		{input: []byte{0x6D, 0xF2}, expected: "push.w r2"},
+		{input: []byte{0x6D, 0xB2}, expected: "mov.w r2, @-er3"},
+		{input: []byte{0x01, 0x00, 0x6D, 0x45}, expected: "mov.l @er4+, er5"},
+		{input: []byte{0x5F, 0x20}, expected: "jsr @@0x20:8"},
+		{input: []byte{0x01, 0x30, 0x6D, 0x77}, expected: "ldm.l @sp+, (er4-er7)"},
+		{input: []byte{0x01, 0x10, 0x6D, 0xF2}, expected: "stm.l (er2-er3), @-sp"},
+	}
+	for _, tc := range testCases {
+		inst := decode(tc.input)
+		assert.Equal(t, tc.expected, inst.String())
+		encoded, err := instruction.Encode(inst)
+		assert.NoError(t, err, tc.expected)
+		assert.Equal(t, tc.input, encoded, tc.expected)
+	}
+}
+
+// Every valid instruction must encode, and decode back to itself.
+func TestEncodeRoundTrip(t *testing.T) {
+	bytes := make([]byte, 10)
+	for first := 0; first < 0x10000; first++ {
+		bytes[0], bytes[1] = byte(first>>8), byte(first)
+		for n := 2; n < len(bytes); n++ {
+			bytes[n] = byte(first*7 + n*31)
+		}
+		inst := decode(bytes)
+		if inst.Opcode == opcode.Invalid {
+			continue
+		}
+		b, err := instruction.Encode(inst)
+		if !assert.NoError(t, err, "% X", inst.Bytes) {
+			continue
+		}
+		again := decode(b)
+		assert.Equal(t, inst.String(), again.String(), "% X encoded as % X", inst.Bytes, b)
+		assert.Equal(t, inst.OperandType, again.OperandType, "% X encoded as % X", inst.Bytes, b)
+		assert.Equal(t, inst.Operands(), again.Operands(), "% X encoded as % X", inst.Bytes, b)
+	}
+}
+
+func TestEncodeNoEncoding(t *testing.T) {
+	inst := instruction.Inst{
+		Opcode:         opcode.Mov,
+		BWL:            size.Byte,
+		AddressingMode: addressingmode.RegisterDirect,
+		OperandType:    operand.R8_R8,
+	}
+	inst.SetOperands(
+		instruction.Register{Number: 16, Size: size.Byte}.Operand(),
+		instruction.Register{Number: 2, Size: size.Byte}.Operand(),
+	)
+	_, err := instruction.Encode(inst)
+	assert.ErrorIs(t, err, instruction.ErrNoEncoding)
+
+	// ldm can only load er0-er1, er2-er3, er4-er5 or er6-er7 in pairs.
+	inst = decode([]byte{0x01, 0x10, 0x6D, 0x72})
+	_, err = inst.Format()
+	assert.ErrorIs(t, err, instruction.ErrUnrenderable)
+	_, err = instruction.Encode(inst)
+	assert.ErrorIs(t, err, instruction.ErrNoEncoding)
+}
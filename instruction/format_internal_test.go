@@ -0,0 +1,110 @@
+package instruction
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/kn100/cybemu/opcode"
+	"github.com/kn100/cybemu/operand"
+	"github.com/stretchr/testify/assert"
+)
+
+// Every operand type must have a layout, or instructions with it can't be
+// written out.
+func TestEveryOperandTypeHasLayout(t *testing.T) {
+	for ot := operand.Unknown + 1; ot < operand.Count; ot++ {
+		_, ok := layouts[ot]
+		assert.True(t, ok, "operand type %d has no layout", ot)
+	}
+	_, ok := layouts[operand.Unknown]
+	assert.False(t, ok)
+}
+
+// Every form in the table must have an encoding which Format can render.
+// Most render from the fixed bits alone, but ldm and stm need a register
+// which starts a list they can use, so a few of the field bits are tried too.
+func TestEveryFormFormats(t *testing.T) {
+	for n := range forms {
+		f := &forms[n]
+		var free []int
+		for b := 0; b < 64 && len(free) < 4; b++ {
+			if b < 64-8*f.length || f.mask&(1<<b) != 0 {
+				continue
+			}
+			free = append(free, b)
+		}
+		var err error
+		for k := 0; k < 1<<len(free); k++ {
+			w := f.value
+			for j, b := range free {
+				if k&(1<<j) != 0 {
+					w |= 1 << b
+				}
+			}
+			i := &Inst{Opcode: f.opcode, BWL: f.bwl, AddressingMode: f.mode, Bytes: bytesOf(w, f.length)}
+			i.DetermineOperandTypeAndSetData()
+			if _, err = i.Format(); err == nil {
+				break
+			}
+		}
+		assert.NoError(t, err, "form %d, %s with operand type %d", n, f.opcode, f.operandType)
+	}
+}
+
+// Every complete form must encode, and its encoding must decode to the same
+// instruction. Register lists are tried as in TestEveryFormFormats.
+func TestEveryCompleteFormEncodes(t *testing.T) {
+	for n := range forms {
+		f := &forms[n]
+		if !f.complete {
+			continue
+		}
+		var free []int
+		for b := 0; b < 64 && len(free) < 4; b++ {
+			if b < 64-8*f.length || f.mask&(1<<b) != 0 {
+				continue
+			}
+			free = append(free, b)
+		}
+		var i *Inst
+		for k := 0; k < 1<<len(free); k++ {
+			w := f.value
+			for j, b := range free {
+				if k&(1<<j) != 0 {
+					w |= 1 << b
+				}
+			}
+			i = &Inst{Opcode: f.opcode, BWL: f.bwl, AddressingMode: f.mode, Bytes: bytesOf(w, f.length)}
+			i.DetermineOperandTypeAndSetData()
+			if _, err := i.Format(); err == nil {
+				break
+			}
+		}
+		b, err := Encode(*i)
+		if !assert.NoError(t, err, "form %d, %s", n, i) {
+			continue
+		}
+		again := Decode(b)
+		again.Bytes = b
+		again.DetermineOperandTypeAndSetData()
+		assert.Equal(t, i.String(), again.String(), "form %d, % X encoded as % X", n, i.Bytes, b)
+		assert.Equal(t, i.OperandType, again.OperandType, "form %d, % X encoded as % X", n, i.Bytes, b)
+		assert.Equal(t, i.Operands(), again.Operands(), "form %d, % X encoded as % X", n, i.Bytes, b)
+	}
+}
+
+func TestFormatUnknownOperandType(t *testing.T) {
+	i := &Inst{Opcode: opcode.Mov, Bytes: []byte{0x01, 0x00}}
+	_, err := i.Format()
+	assert.ErrorIs(t, err, ErrUnrenderable)
+}
+
+// bytesOf returns the first length bytes of an instruction whose first 8 are
+// w, with any after them zero.
+func bytesOf(w uint64, length int) []byte {
+	b := make([]byte, length)
+	for n := 0; n < length && n < 8; n++ {
+		b[n] = byte(bits.RotateLeft64(w, 8*(n+1)))
+	}
+	return b
+}
@@ -0,0 +1,350 @@
+// contains the generator for the instruction package's table.go. It reads the
+// instruction table in instructions.txt, whose format is described at the top
+// of that file, and writes out the forms it describes along with a lookup
+// function which matches bytes against them.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// The operand fields, by the letter the table uses for them.
+var roles = map[byte]string{
+	'd': "regDst",
+	's': "regSrc",
+	'r': "reg",
+	'i': "imm",
+	'l': "immL",
+	'm': "immR",
+	'c': "ctl",
+}
+
+var sizes = map[string]string{
+	"-": "Unset",
+	"B": "Byte",
+	"W": "Word",
+	"L": "Longword",
+}
+
+// maxFixedBits is how far into an instruction fixed bits can be, since lookup
+// matches against the first 8 bytes.
+const maxFixedBits = 64
+
+type field struct {
+	role  byte
+	start int // the first bit, counting from the top of the first byte
+	width int
+}
+
+type form struct {
+	line        int
+	text        string
+	opcode      string
+	size        string
+	mode        string
+	operandSize int
+	operandType string
+	constants   []string
+	length      int
+	mask, value uint64
+	// complete is set when every bit is fixed, zero or in a field.
+	complete bool
+	fields   []field
+}
+
+func main() {
+	in := flag.String("in", "instructions.txt", "the instruction table")
+	out := flag.String("out", "table.go", "where to write the generated code")
+	flag.Parse()
+
+	forms, err := read(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := checkReachable(forms); err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(*in, forms)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func read(name string) ([]form, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var forms []form
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		form, err := parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", name, n, err)
+		}
+		form.line = n
+		forms = append(forms, form)
+	}
+	return forms, scanner.Err()
+}
+
+func parse(line string) (form, error) {
+	words := strings.Fields(line)
+	if len(words) < 6 {
+		return form{}, fmt.Errorf("expected opcode, size, mode, operand size, operand type and pattern")
+	}
+	f := form{
+		opcode:      words[0],
+		mode:        words[2],
+		operandType: words[4],
+	}
+	var ok bool
+	if f.size, ok = sizes[words[1]]; !ok {
+		return form{}, fmt.Errorf("unknown size %q", words[1])
+	}
+	var err error
+	if f.operandSize, err = strconv.Atoi(words[3]); err != nil {
+		return form{}, fmt.Errorf("bad operand size: %w", err)
+	}
+	rest := words[5:]
+	for len(rest) > 0 && strings.Contains(rest[0], "=") {
+		f.constants = append(f.constants, rest[0])
+		rest = rest[1:]
+	}
+	f.text = strings.Join(rest, " ")
+	if err := f.parsePattern(strings.Join(rest, "")); err != nil {
+		return form{}, err
+	}
+	for _, c := range f.constants {
+		letter, value, _ := strings.Cut(c, "=")
+		if len(letter) != 1 || roles[letter[0]] == "" {
+			return form{}, fmt.Errorf("unknown field in %q", c)
+		}
+		if _, err := strconv.ParseUint(value, 0, 8); err != nil {
+			return form{}, fmt.Errorf("bad value in %q: %w", c, err)
+		}
+		for _, field := range f.fields {
+			if field.role == letter[0] {
+				return form{}, fmt.Errorf("%q is also in the pattern", c)
+			}
+		}
+	}
+	return f, nil
+}
+
+// parsePattern works out the mask, value, length and fields of a pattern with
+// its spaces removed.
+func (f *form) parsePattern(pattern string) error {
+	bit := 0
+	fields := map[byte]*field{}
+	var order []byte
+	f.complete = true
+	set := func(kind byte) error {
+		switch {
+		case kind == '0' || kind == '1':
+			if bit >= maxFixedBits {
+				return fmt.Errorf("fixed bits past the first %d", maxFixedBits)
+			}
+			f.mask |= 1 << (63 - bit)
+			if kind == '1' {
+				f.value |= 1 << (63 - bit)
+			}
+		case kind == '.':
+			f.complete = false
+		case kind == '-':
+		case roles[kind] != "":
+			fl, ok := fields[kind]
+			if !ok {
+				fl = &field{role: kind, start: bit}
+				fields[kind] = fl
+				order = append(order, kind)
+			}
+			if fl.start+fl.width != bit {
+				return fmt.Errorf("field %c isn't contiguous", kind)
+			}
+			fl.width++
+		default:
+			return fmt.Errorf("unexpected %q in pattern", kind)
+		}
+		return nil
+	}
+
+	for n := 0; n < len(pattern); {
+		c := pattern[n]
+		switch {
+		case c == '[':
+			end := strings.IndexByte(pattern[n:], ']')
+			if end != 5 {
+				return fmt.Errorf("expected four bits in brackets at %q", pattern[n:])
+			}
+			for _, b := range []byte(pattern[n+1 : n+5]) {
+				if err := set(b); err != nil {
+					return err
+				}
+				bit++
+			}
+			n += 6
+		case strings.IndexByte("0123456789ABCDEF", c) >= 0:
+			v, _ := strconv.ParseUint(string(c), 16, 8)
+			for b := 3; b >= 0; b-- {
+				if err := set('0' + byte(v>>b&1)); err != nil {
+					return err
+				}
+				bit++
+			}
+			n++
+		case c == '.' || c == '-' || roles[c] != "":
+			// A field letter can be followed by bits in braces, which the
+			// field must match.
+			if roles[c] != "" && n+1 < len(pattern) && pattern[n+1] == '{' {
+				if err := f.parsePatternBits(pattern[n+1:], bit); err != nil {
+					return err
+				}
+				n += 6
+			}
+			for b := 0; b < 4; b++ {
+				if err := set(c); err != nil {
+					return err
+				}
+				bit++
+			}
+			n++
+		default:
+			return fmt.Errorf("unexpected %q in pattern", c)
+		}
+	}
+
+	if bit%16 != 0 {
+		return fmt.Errorf("pattern is %d bits, which isn't a whole number of words", bit)
+	}
+	f.length = bit / 8
+	for _, kind := range order {
+		fl := *fields[kind]
+		inByte := fl.start/8 == (fl.start+fl.width-1)/8
+		aligned := fl.start%8 == 0 && fl.width%8 == 0
+		if !inByte && !aligned {
+			return fmt.Errorf("field %c must be within a byte, or whole bytes", kind)
+		}
+		f.fields = append(f.fields, fl)
+	}
+	return nil
+}
+
+// parsePatternBits adds the bits in braces at the start of pattern to the mask
+// and value, for a field which overlaps them.
+func (f *form) parsePatternBits(pattern string, bit int) error {
+	if len(pattern) < 6 || pattern[0] != '{' || pattern[5] != '}' {
+		return fmt.Errorf("expected four bits in braces at %q", pattern)
+	}
+	if bit+4 > maxFixedBits {
+		return fmt.Errorf("fixed bits past the first %d", maxFixedBits)
+	}
+	for n, b := range []byte(pattern[1:5]) {
+		switch b {
+		case '0', '1':
+			f.mask |= 1 << (63 - bit - n)
+			if b == '1' {
+				f.value |= 1 << (63 - bit - n)
+			}
+		case '.':
+		default:
+			return fmt.Errorf("unexpected %q in bits following a field", b)
+		}
+	}
+	return nil
+}
+
+// checkReachable returns an error if any form can't be matched, because an
+// earlier form matches everything it does.
+func checkReachable(forms []form) error {
+	for j, later := range forms {
+		for _, earlier := range forms[:j] {
+			if earlier.mask&^later.mask == 0 && later.value&earlier.mask == earlier.value {
+				return fmt.Errorf("line %d: can't be matched, because line %d matches first", later.line, earlier.line)
+			}
+		}
+	}
+	return nil
+}
+
+func generate(name string, forms []form) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by \"gen -in %s\"; DO NOT EDIT.\n\n", name)
+	b.WriteString("package instruction\n\n")
+	b.WriteString("import (\n")
+	for _, pkg := range []string{"addressingmode", "opcode", "operand", "size"} {
+		fmt.Fprintf(&b, "\t\"github.com/kn100/cybemu/%s\"\n", pkg)
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("var forms = [...]form{\n")
+	for n, f := range forms {
+		fmt.Fprintf(&b, "\t%d: { // %s\n", n, f.text)
+		fmt.Fprintf(&b, "\t\tmask: 0x%016X, value: 0x%016X, length: %d, complete: %t,\n", f.mask, f.value, f.length, f.complete)
+		fmt.Fprintf(&b, "\t\topcode: opcode.%s, bwl: size.%s, mode: addressingmode.%s,\n", f.opcode, f.size, f.mode)
+		fmt.Fprintf(&b, "\t\toperandSize: %d, operandType: operand.%s,\n", f.operandSize, f.operandType)
+		if len(f.fields) > 0 || len(f.constants) > 0 {
+			b.WriteString("\t\tfields: []field{")
+			for _, fl := range f.fields {
+				fmt.Fprintf(&b, "{role: %s, start: %d, width: %d}, ", roles[fl.role], fl.start, fl.width)
+			}
+			for _, c := range f.constants {
+				letter, value, _ := strings.Cut(c, "=")
+				fmt.Fprintf(&b, "{role: %s, value: %s}, ", roles[letter[0]], value)
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("\t},\n")
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("// lookup returns the index in forms of the first form matching the\n")
+	b.WriteString("// instruction starting with the 8 bytes in w, or -1 if there isn't one.\n")
+	b.WriteString("func lookup(w uint64) int {\n")
+	b.WriteString("\tswitch w >> 56 {\n")
+	for first := 0; first < 256; first++ {
+		var matching []int
+		for n, f := range forms {
+			if uint64(first)<<56&f.mask == f.value&0xFF00000000000000 {
+				matching = append(matching, n)
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\tcase 0x%02X:\n", first)
+		for _, n := range matching {
+			f := forms[n]
+			rest := f.mask &^ 0xFF00000000000000
+			if rest == 0 {
+				fmt.Fprintf(&b, "\t\treturn %d\n", n)
+				break
+			}
+			fmt.Fprintf(&b, "\t\tif w&0x%016X == 0x%016X {\n\t\t\treturn %d\n\t\t}\n", rest, f.value&rest, n)
+		}
+	}
+	b.WriteString("\t}\n\treturn -1\n}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, b.Bytes())
+	}
+	return src, nil
+}
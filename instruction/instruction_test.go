@@ -16,8 +16,7 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 	testCases := []struct {
 		instruction         instruction.Inst
 		expectedOperandType operand.OperandType
-		expectedRegSrc      []byte
-		expectedRegDst      []byte
+		expectedOperands    []instruction.Operand
 		expectedString      string
 	}{
 		{
@@ -27,8 +26,6 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.None,
 			},
-			expectedRegSrc:      nil,
-			expectedRegDst:      nil,
 			expectedOperandType: operand.None,
 			expectedString:      "nop",
 		},
@@ -39,9 +36,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x03},
-			expectedRegDst:      []byte{0x0E},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 3, Size: size.Byte}.Operand(), instruction.Register{Number: 14, Size: size.Byte}.Operand()},
 			expectedString:      "add.b r3h, r6l",
 		},
 		{
@@ -51,9 +47,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x00},
-			expectedRegDst:      []byte{0x0B},
 			expectedOperandType: operand.R16_R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 0, Size: size.Word}.Operand(), instruction.Register{Number: 11, Size: size.Word}.Operand()},
 			expectedString:      "add.w r0, e3",
 		},
 		{
@@ -63,9 +58,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0E},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.R32_R32_S2,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 6, Size: size.Longword}.Operand(), instruction.Register{Number: 0, Size: size.Longword}.Operand()},
 			expectedString:      "add.l er6, er0",
 		},
 		{
@@ -75,9 +69,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0F},
-			expectedRegDst:      []byte{0x07},
 			expectedOperandType: operand.R32_R32_S2,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 7, Size: size.Longword}.Operand(), instruction.Register{Number: 7, Size: size.Longword}.Operand()},
 			expectedString:      "add.l sp, sp",
 		},
 		{
@@ -87,9 +80,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      nil,
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_ADDS_SUBS,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "adds #1, er6",
 		},
 		{
@@ -99,9 +91,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      nil,
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_ADDS_SUBS,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "adds #2, er6",
 		},
 		{
@@ -111,9 +102,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      nil,
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_ADDS_SUBS,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 4}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "adds #4, er6",
 		},
 		{
@@ -123,9 +113,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0C},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 12, Size: size.Byte}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "addx r4l, r0h",
 		},
 		{
@@ -135,9 +124,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x03},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 3, Size: size.Byte}.Operand(), instruction.Register{Number: 2, Size: size.Byte}.Operand()},
 			expectedString:      "and.b r3h, r2h",
 		},
 		{
@@ -147,9 +135,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x02},
-			expectedRegDst:      []byte{0x0E},
 			expectedOperandType: operand.R16_R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 2, Size: size.Word}.Operand(), instruction.Register{Number: 14, Size: size.Word}.Operand()},
 			expectedString:      "and.w r2, e6",
 		},
 		{
@@ -159,9 +146,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x00},
-			expectedRegDst:      []byte{0x07},
 			expectedOperandType: operand.R32_R32_S4,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 0, Size: size.Longword}.Operand(), instruction.Register{Number: 7, Size: size.Longword}.Operand()},
 			expectedString:      "and.l er0, sp",
 		},
 		{
@@ -171,8 +157,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "band #5, r0h",
 		},
 		{
@@ -182,8 +168,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "bclr #5, r0h",
 		},
 		{
@@ -193,9 +179,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x09},
-			expectedRegDst:      []byte{0x03},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 9, Size: size.Byte}.Operand(), instruction.Register{Number: 3, Size: size.Byte}.Operand()},
 			expectedString:      "bclr r1l, r3h",
 		},
 		{
@@ -205,8 +190,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 4}.Operand(), instruction.Register{Number: 2, Size: size.Byte}.Operand()},
 			expectedString:      "biand #4, r2h",
 		},
 		{
@@ -216,8 +201,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 4}.Operand(), instruction.Register{Number: 2, Size: size.Byte}.Operand()},
 			expectedString:      "bild #4, r2h",
 		},
 		{
@@ -227,8 +212,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 4}.Operand(), instruction.Register{Number: 2, Size: size.Byte}.Operand()},
 			expectedString:      "bior #4, r2h",
 		},
 		{
@@ -238,8 +223,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 4}.Operand(), instruction.Register{Number: 2, Size: size.Byte}.Operand()},
 			expectedString:      "bist #4, r2h",
 		},
 		{
@@ -249,8 +234,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 4}.Operand(), instruction.Register{Number: 2, Size: size.Byte}.Operand()},
 			expectedString:      "bixor #4, r2h",
 		},
 		{
@@ -260,19 +245,19 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 4}.Operand(), instruction.Register{Number: 2, Size: size.Byte}.Operand()},
 			expectedString:      "bld #4, r2h",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x72, 0x50},
+				Bytes:          []byte{0x71, 0x50},
 				Opcode:         opcode.Bnot,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "bnot #5, r0h",
 		},
 		{
@@ -282,9 +267,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x09},
-			expectedRegDst:      []byte{0x03},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 9, Size: size.Byte}.Operand(), instruction.Register{Number: 3, Size: size.Byte}.Operand()},
 			expectedString:      "bnot r1l, r3h",
 		},
 		{
@@ -294,8 +278,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "bor #5, r0h",
 		},
 		{
@@ -305,8 +289,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "bset #5, r0h",
 		},
 		{
@@ -316,9 +300,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x09},
-			expectedRegDst:      []byte{0x03},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 9, Size: size.Byte}.Operand(), instruction.Register{Number: 3, Size: size.Byte}.Operand()},
 			expectedString:      "bset r1l, r3h",
 		},
 		{
@@ -328,8 +311,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "bst #5, r0h",
 		},
 		{
@@ -339,8 +322,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "btst #5, r0h",
 		},
 		{
@@ -350,9 +333,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x09},
-			expectedRegDst:      []byte{0x03},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 9, Size: size.Byte}.Operand(), instruction.Register{Number: 3, Size: size.Byte}.Operand()},
 			expectedString:      "btst r1l, r3h",
 		},
 		{
@@ -362,8 +344,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.Ix_R8,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "bxor #5, r0h",
 		},
 		{
@@ -373,9 +355,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x03},
-			expectedRegDst:      []byte{0x0E},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 3, Size: size.Byte}.Operand(), instruction.Register{Number: 14, Size: size.Byte}.Operand()},
 			expectedString:      "cmp.b r3h, r6l",
 		},
 		{
@@ -385,9 +366,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0D},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.R16_R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 13, Size: size.Word}.Operand(), instruction.Register{Number: 2, Size: size.Word}.Operand()},
 			expectedString:      "cmp.w e5, r2",
 		},
 		{
@@ -397,9 +377,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0B},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.R32_R32_S2,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 3, Size: size.Longword}.Operand(), instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "cmp.l er3, er5",
 		},
 		{
@@ -409,8 +388,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "daa r0h",
 		},
 		{
@@ -420,8 +399,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0C},
 			expectedOperandType: operand.R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 12, Size: size.Byte}.Operand()},
 			expectedString:      "daa r4l",
 		},
 		{
@@ -431,8 +410,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "das r0h",
 		},
 		{
@@ -442,8 +421,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0C},
 			expectedOperandType: operand.R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 12, Size: size.Byte}.Operand()},
 			expectedString:      "das r4l",
 		},
 		{
@@ -453,8 +432,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R8_INC_DEC,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 5, Size: size.Byte}.Operand()},
 			expectedString:      "dec.b #1, r5h",
 		},
 		{
@@ -464,8 +443,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R16_INC_DEC,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 5, Size: size.Word}.Operand()},
 			expectedString:      "dec.w #1, r5",
 		},
 		{
@@ -475,8 +454,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R16_INC_DEC,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 5, Size: size.Word}.Operand()},
 			expectedString:      "dec.w #2, r5",
 		},
 		{
@@ -486,8 +465,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R32_INC_DEC,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "dec.l #1, er5",
 		},
 		{
@@ -497,8 +476,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R32_INC_DEC,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "dec.l #2, er5",
 		},
 		{
@@ -508,9 +487,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0B},
-			expectedRegDst:      []byte{0x0C},
 			expectedOperandType: operand.R8_R16_MULXS_DIVXS,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 11, Size: size.Byte}.Operand(), instruction.Register{Number: 12, Size: size.Word}.Operand()},
 			expectedString:      "divxs.b r3l, e4",
 		},
 		{
@@ -520,9 +498,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0B},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.R16_R32_MULXS_DIVXS,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 11, Size: size.Word}.Operand(), instruction.Register{Number: 2, Size: size.Longword}.Operand()},
 			expectedString:      "divxs.w e3, er2",
 		},
 		{
@@ -532,9 +509,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0B},
-			expectedRegDst:      []byte{0x0C},
 			expectedOperandType: operand.R8_R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 11, Size: size.Byte}.Operand(), instruction.Register{Number: 12, Size: size.Word}.Operand()},
 			expectedString:      "divxu.b r3l, e4",
 		},
 		{
@@ -544,9 +520,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0B},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.R16_R32,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 11, Size: size.Word}.Operand(), instruction.Register{Number: 2, Size: size.Longword}.Operand()},
 			expectedString:      "divxu.w e3, er2",
 		},
 		{
@@ -556,8 +531,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 2, Size: size.Word}.Operand()},
 			expectedString:      "exts.w r2",
 		},
 		{
@@ -567,8 +542,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.R32,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "exts.l er6",
 		},
 		{
@@ -578,8 +553,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x03},
 			expectedOperandType: operand.R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 3, Size: size.Word}.Operand()},
 			expectedString:      "extu.w r3",
 		},
 		{
@@ -589,8 +564,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.R32,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "extu.l er5",
 		},
 
@@ -601,8 +576,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R8_INC_DEC,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 5, Size: size.Byte}.Operand()},
 			expectedString:      "inc.b #1, r5h",
 		},
 		{
@@ -612,8 +587,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R16_INC_DEC,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 5, Size: size.Word}.Operand()},
 			expectedString:      "inc.w #1, r5",
 		},
 		{
@@ -623,8 +598,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R16_INC_DEC,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 5, Size: size.Word}.Operand()},
 			expectedString:      "inc.w #2, r5",
 		},
 		{
@@ -634,8 +609,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R32_INC_DEC,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "inc.l #1, er5",
 		},
 		{
@@ -645,8 +620,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R32_INC_DEC,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "inc.l #2, er5",
 		},
 		{
@@ -656,8 +631,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x04},
 			expectedOperandType: operand.R8_LDC,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 4, Size: size.Byte}.Operand(), instruction.CCR.Operand()},
 			expectedString:      "ldc.b r4h, ccr",
 		},
 		{
@@ -667,8 +642,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x04},
 			expectedOperandType: operand.R8_LDC,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 4, Size: size.Byte}.Operand(), instruction.EXR.Operand()},
 			expectedString:      "ldc.b r4h, exr",
 		},
 		{
@@ -678,9 +653,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0D},
-			expectedRegDst:      []byte{0x04},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 13, Size: size.Byte}.Operand(), instruction.Register{Number: 4, Size: size.Byte}.Operand()},
 			expectedString:      "mov.b r5l, r4h",
 		},
 		{
@@ -690,9 +664,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x04},
-			expectedRegDst:      []byte{0x0A},
 			expectedOperandType: operand.R16_R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 4, Size: size.Word}.Operand(), instruction.Register{Number: 10, Size: size.Word}.Operand()},
 			expectedString:      "mov.w r4, e2",
 		},
 		{
@@ -702,9 +675,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x08},
-			expectedRegDst:      []byte{0x01},
 			expectedOperandType: operand.R32_R32_S2,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 0, Size: size.Longword}.Operand(), instruction.Register{Number: 1, Size: size.Longword}.Operand()},
 			expectedString:      "mov.l er0, er1",
 		},
 		{
@@ -714,9 +686,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x04},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.R8_R16_MULXS_DIVXS,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 4, Size: size.Byte}.Operand(), instruction.Register{Number: 2, Size: size.Word}.Operand()},
 			expectedString:      "mulxs.b r4h, r2",
 		},
 		{
@@ -726,9 +697,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x02},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.R16_R32_MULXS_DIVXS,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 2, Size: size.Word}.Operand(), instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "mulxs.w r2, er5",
 		},
 		{
@@ -738,9 +708,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x04},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.R8_R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 4, Size: size.Byte}.Operand(), instruction.Register{Number: 2, Size: size.Word}.Operand()},
 			expectedString:      "mulxu.b r4h, r2",
 		},
 		{
@@ -750,9 +719,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x02},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.R16_R32,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 2, Size: size.Word}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "mulxu.w r2, er6",
 		},
 		{
@@ -762,8 +730,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x08},
 			expectedOperandType: operand.R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 8, Size: size.Byte}.Operand()},
 			expectedString:      "neg.b r0l",
 		},
 		{
@@ -773,8 +741,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0C},
 			expectedOperandType: operand.R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 12, Size: size.Word}.Operand()},
 			expectedString:      "neg.w e4",
 		},
 		{
@@ -784,8 +752,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.R32,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "neg.l er5",
 		},
 		{
@@ -795,8 +763,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x08},
 			expectedOperandType: operand.R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 8, Size: size.Byte}.Operand()},
 			expectedString:      "not.b r0l",
 		},
 		{
@@ -806,8 +774,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0C},
 			expectedOperandType: operand.R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 12, Size: size.Word}.Operand()},
 			expectedString:      "not.w e4",
 		},
 		{
@@ -817,8 +785,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.R32,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "not.l er5",
 		},
 		{
@@ -828,21 +796,19 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x08},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 8, Size: size.Byte}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "or.b r0l, r0h",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x14, 0x80},
+				Bytes:          []byte{0x64, 0x80},
 				Opcode:         opcode.Or,
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x08},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.R16_R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 8, Size: size.Word}.Operand(), instruction.Register{Number: 0, Size: size.Word}.Operand()},
 			expectedString:      "or.w e0, r0",
 		},
 		{
@@ -852,9 +818,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x00},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.R32_R32_S4,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 0, Size: size.Longword}.Operand(), instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "or.l er0, er5",
 		},
 		{
@@ -864,8 +829,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x01},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 1, Size: size.Byte}.Operand()},
 			expectedString:      "rotl.b #1, r1h",
 		},
 		{
@@ -875,8 +840,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x08},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 8, Size: size.Byte}.Operand()},
 			expectedString:      "rotl.b #2, r0l",
 		},
 		{
@@ -886,8 +851,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0C},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 12, Size: size.Word}.Operand()},
 			expectedString:      "rotl.w #1, e4",
 		},
 		{
@@ -897,8 +862,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x09},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 9, Size: size.Word}.Operand()},
 			expectedString:      "rotl.w #2, e1",
 		},
 		{
@@ -908,8 +873,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "rotl.l #1, er6",
 		},
 		{
@@ -919,8 +884,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "rotl.l #2, er6",
 		},
 		{
@@ -930,8 +895,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x01},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 1, Size: size.Byte}.Operand()},
 			expectedString:      "rotr.b #1, r1h",
 		},
 		{
@@ -941,8 +906,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x08},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 8, Size: size.Byte}.Operand()},
 			expectedString:      "rotr.b #2, r0l",
 		},
 		{
@@ -952,8 +917,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0C},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 12, Size: size.Word}.Operand()},
 			expectedString:      "rotr.w #1, e4",
 		},
 		{
@@ -963,8 +928,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x09},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 9, Size: size.Word}.Operand()},
 			expectedString:      "rotr.w #2, e1",
 		},
 		{
@@ -974,8 +939,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "rotr.l #1, er6",
 		},
 		{
@@ -985,8 +950,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "rotr.l #2, er6",
 		},
 		{
@@ -996,8 +961,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x03},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 3, Size: size.Byte}.Operand()},
 			expectedString:      "rotxl.b #1, r3h",
 		},
 		{
@@ -1007,8 +972,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x03},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 3, Size: size.Byte}.Operand()},
 			expectedString:      "rotxl.b #2, r3h",
 		},
 		{
@@ -1018,8 +983,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 6, Size: size.Word}.Operand()},
 			expectedString:      "rotxl.w #1, r6",
 		},
 		{
@@ -1029,8 +994,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 6, Size: size.Word}.Operand()},
 			expectedString:      "rotxl.w #2, r6",
 		},
 		{
@@ -1040,8 +1005,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "rotxl.l #1, er6",
 		},
 		{
@@ -1051,8 +1016,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x03},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 3, Size: size.Byte}.Operand()},
 			expectedString:      "rotxr.b #1, r3h",
 		},
 		{
@@ -1062,8 +1027,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x03},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 3, Size: size.Byte}.Operand()},
 			expectedString:      "rotxr.b #2, r3h",
 		},
 		{
@@ -1073,8 +1038,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 6, Size: size.Word}.Operand()},
 			expectedString:      "rotxr.w #1, r6",
 		},
 		{
@@ -1084,8 +1049,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 6, Size: size.Word}.Operand()},
 			expectedString:      "rotxr.w #2, r6",
 		},
 		{
@@ -1095,8 +1060,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "rotxr.l #1, er6",
 		},
 		{
@@ -1106,8 +1071,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 5, Size: size.Byte}.Operand()},
 			expectedString:      "shal.b #1, r5h",
 		},
 		{
@@ -1117,8 +1082,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0B},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 11, Size: size.Word}.Operand()},
 			expectedString:      "shal.w #1, e3",
 		},
 		{
@@ -1128,8 +1093,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x03},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 3, Size: size.Longword}.Operand()},
 			expectedString:      "shal.l #1, er3",
 		},
 		{
@@ -1139,8 +1104,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 5, Size: size.Byte}.Operand()},
 			expectedString:      "shar.b #1, r5h",
 		},
 		{
@@ -1150,8 +1115,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0B},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 11, Size: size.Word}.Operand()},
 			expectedString:      "shar.w #1, e3",
 		},
 		{
@@ -1161,8 +1126,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x03},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 3, Size: size.Longword}.Operand()},
 			expectedString:      "shar.l #1, er3",
 		},
 		{
@@ -1172,8 +1137,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0E},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 14, Size: size.Byte}.Operand()},
 			expectedString:      "shll.b #1, r6l",
 		},
 		{
@@ -1183,8 +1148,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 5, Size: size.Byte}.Operand()},
 			expectedString:      "shll.b #2, r5h",
 		},
 		{
@@ -1194,8 +1159,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x04},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 4, Size: size.Word}.Operand()},
 			expectedString:      "shll.w #1, r4",
 		},
 		{
@@ -1205,8 +1170,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0E},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 14, Size: size.Word}.Operand()},
 			expectedString:      "shll.w #2, e6",
 		},
 		{
@@ -1216,8 +1181,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 2, Size: size.Longword}.Operand()},
 			expectedString:      "shll.l #1, er2",
 		},
 		{
@@ -1227,8 +1192,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "shll.l #2, er5",
 		},
 		{
@@ -1238,8 +1203,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0E},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 14, Size: size.Byte}.Operand()},
 			expectedString:      "shlr.b #1, r6l",
 		},
 		{
@@ -1249,8 +1214,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R8_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 5, Size: size.Byte}.Operand()},
 			expectedString:      "shlr.b #2, r5h",
 		},
 		{
@@ -1260,8 +1225,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x04},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 4, Size: size.Word}.Operand()},
 			expectedString:      "shlr.w #1, r4",
 		},
 		{
@@ -1271,8 +1236,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x0E},
 			expectedOperandType: operand.Ix_R16_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 14, Size: size.Word}.Operand()},
 			expectedString:      "shlr.w #2, e6",
 		},
 		{
@@ -1282,8 +1247,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x02},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 2, Size: size.Longword}.Operand()},
 			expectedString:      "shlr.l #1, er2",
 		},
 		{
@@ -1293,8 +1258,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x05},
 			expectedOperandType: operand.Ix_R32_SH,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "shlr.l #2, er5",
 		},
 		{
@@ -1304,8 +1269,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x04},
 			expectedOperandType: operand.R8_STC,
+			expectedOperands:    []instruction.Operand{instruction.CCR.Operand(), instruction.Register{Number: 4, Size: size.Byte}.Operand()},
 			expectedString:      "stc.b ccr, r4h",
 		},
 		{
@@ -1315,8 +1280,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegDst:      []byte{0x04},
 			expectedOperandType: operand.R8_STC,
+			expectedOperands:    []instruction.Operand{instruction.EXR.Operand(), instruction.Register{Number: 4, Size: size.Byte}.Operand()},
 			expectedString:      "stc.b exr, r4h",
 		},
 		{
@@ -1326,9 +1291,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x03},
-			expectedRegDst:      []byte{0x0E},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 3, Size: size.Byte}.Operand(), instruction.Register{Number: 14, Size: size.Byte}.Operand()},
 			expectedString:      "sub.b r3h, r6l",
 		},
 		{
@@ -1338,9 +1302,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x00},
-			expectedRegDst:      []byte{0x0B},
 			expectedOperandType: operand.R16_R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 0, Size: size.Word}.Operand(), instruction.Register{Number: 11, Size: size.Word}.Operand()},
 			expectedString:      "sub.w r0, e3",
 		},
 		{
@@ -1350,9 +1313,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x08},
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.R32_R32_S2,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 0, Size: size.Longword}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "sub.l er0, er6",
 		},
 		{
@@ -1362,9 +1324,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0F},
-			expectedRegDst:      []byte{0x07},
 			expectedOperandType: operand.R32_R32_S2,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 7, Size: size.Longword}.Operand(), instruction.Register{Number: 7, Size: size.Longword}.Operand()},
 			expectedString:      "sub.l sp, sp",
 		},
 		{
@@ -1374,9 +1335,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      nil,
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_ADDS_SUBS,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "subs #1, er6",
 		},
 		{
@@ -1386,9 +1346,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      nil,
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_ADDS_SUBS,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "subs #2, er6",
 		},
 		{
@@ -1398,9 +1357,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      nil,
-			expectedRegDst:      []byte{0x06},
 			expectedOperandType: operand.Ix_R32_ADDS_SUBS,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 4}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "subs #4, er6",
 		},
 		{
@@ -1410,9 +1368,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0C},
-			expectedRegDst:      []byte{0x00},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 12, Size: size.Byte}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "subx r4l, r0h",
 		},
 		{
@@ -1422,9 +1379,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      nil,
-			expectedRegDst:      nil,
 			expectedOperandType: operand.TRAPA_Ix,
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 2}.Operand()},
 			expectedString:      "trapa #2",
 		},
 		{
@@ -1434,9 +1390,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Byte,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x04},
-			expectedRegDst:      []byte{0x0C},
 			expectedOperandType: operand.R8_R8,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 4, Size: size.Byte}.Operand(), instruction.Register{Number: 12, Size: size.Byte}.Operand()},
 			expectedString:      "xor.b r4h, r4l",
 		},
 		{
@@ -1446,9 +1401,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Word,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x0D},
-			expectedRegDst:      []byte{0x04},
 			expectedOperandType: operand.R16_R16,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 13, Size: size.Word}.Operand(), instruction.Register{Number: 4, Size: size.Word}.Operand()},
 			expectedString:      "xor.w e5, r4",
 		},
 		{
@@ -1458,9 +1412,8 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Longword,
 				AddressingMode: addressingmode.RegisterDirect,
 			},
-			expectedRegSrc:      []byte{0x00},
-			expectedRegDst:      []byte{0x01},
 			expectedOperandType: operand.R32_R32_S4,
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 0, Size: size.Longword}.Operand(), instruction.Register{Number: 1, Size: size.Longword}.Operand()},
 			expectedString:      "xor.l er0, er1",
 		},
 		{
@@ -1470,17 +1423,14 @@ func TestDetermineOperandTypeAndSetDataRegisterDirect(t *testing.T) {
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.None,
 			},
-			expectedRegSrc:      nil,
-			expectedRegDst:      nil,
 			expectedOperandType: operand.Unknown,
-			expectedString:      "invalid :(",
+			expectedString:      ".word 0xFF00",
 		},
 	}
 	for _, tc := range testCases {
 		tc.instruction.DetermineOperandTypeAndSetData()
 		assert.Equal(t, tc.expectedOperandType, tc.instruction.OperandType, "expected operand type to be %s, got %s", tc.expectedOperandType, tc.instruction.OperandType)
-		assert.Equal(t, tc.expectedRegSrc, tc.instruction.RegSrc, "expected RegSrc %v, got %v", tc.expectedRegSrc, tc.instruction.RegSrc)
-		assert.Equal(t, tc.expectedRegDst, tc.instruction.RegDst, "expected RegDst %v, got %v", tc.expectedRegDst, tc.instruction.RegDst)
+		assert.Equal(t, tc.expectedOperands, tc.instruction.Operands())
 		assert.Equal(t, tc.expectedString, tc.instruction.String())
 
 	}
@@ -1490,8 +1440,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 	testCases := []struct {
 		instruction         instruction.Inst
 		expectedOperandType operand.OperandType
-		expectedRegSrc      []byte
-		expectedRegDst      []byte
+		expectedOperands    []instruction.Operand
 		expectedString      string
 	}{
 		{
@@ -1502,7 +1451,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x02},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 4}.Operand(), instruction.Indirect{Register: 2}.Operand()},
 			expectedString:      "band #4, @er2",
 		},
 		{
@@ -1513,7 +1462,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 6}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "bclr #6, @er4",
 		},
 		{
@@ -1524,8 +1473,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.R8_AR32_BCLR,
-			expectedRegSrc:      []byte{0x04},
-			expectedRegDst:      []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 4, Size: size.Byte}.Operand(), instruction.Indirect{Register: 3}.Operand()},
 			expectedString:      "bclr r4h, @er3",
 		},
 		{
@@ -1536,7 +1484,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "biand #7, @er4",
 		},
 		{
@@ -1547,7 +1495,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "bild #7, @er4",
 		},
 		{
@@ -1558,7 +1506,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "bior #7, @er4",
 		},
 		{
@@ -1569,7 +1517,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "bist #7, @er4",
 		},
 		{
@@ -1580,7 +1528,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "bixor #7, @er4",
 		},
 		{
@@ -1591,7 +1539,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "bld #7, @er4",
 		},
 		{
@@ -1602,7 +1550,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "bnot #7, @er4",
 		},
 		{
@@ -1613,8 +1561,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.R8_AR32,
-			expectedRegSrc:      []byte{0x05},
-			expectedRegDst:      []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Byte}.Operand(), instruction.Indirect{Register: 3}.Operand()},
 			expectedString:      "bnot r5h, @er3",
 		},
 		{
@@ -1625,7 +1572,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "bor #7, @er4",
 		},
 		{
@@ -1636,7 +1583,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "bset #7, @er4",
 		},
 		{
@@ -1647,8 +1594,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.R8_AR32,
-			expectedRegSrc:      []byte{0x05},
-			expectedRegDst:      []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Byte}.Operand(), instruction.Indirect{Register: 3}.Operand()},
 			expectedString:      "bset r5h, @er3",
 		},
 		{
@@ -1659,7 +1605,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "bst #7, @er4",
 		},
 		{
@@ -1670,7 +1616,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "btst #7, @er4",
 		},
 		{
@@ -1681,19 +1627,18 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.R8_AR32,
-			expectedRegSrc:      []byte{0x05},
-			expectedRegDst:      []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Byte}.Operand(), instruction.Indirect{Register: 3}.Operand()},
 			expectedString:      "btst r5h, @er3",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x7C, 0x40, 0x73, 0x70},
+				Bytes:          []byte{0x7C, 0x40, 0x75, 0x70},
 				Opcode:         opcode.Bxor,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.Ix_AR32,
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "bxor #7, @er4",
 		},
 		{
@@ -1704,7 +1649,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.AR32_S2,
-			expectedRegDst:      []byte{0x06},
+			expectedOperands:    []instruction.Operand{instruction.Indirect{Register: 6}.Operand()},
 			expectedString:      "jmp @er6",
 		},
 		{
@@ -1715,7 +1660,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.AR32_S2,
-			expectedRegDst:      []byte{0x06},
+			expectedOperands:    []instruction.Operand{instruction.Indirect{Register: 6}.Operand()},
 			expectedString:      "jsr @er6",
 		},
 		{
@@ -1726,7 +1671,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.R8_LDC, // This one is very strange... Check TODO
-			expectedRegSrc:      []byte{0x02},
+			expectedOperands:    []instruction.Operand{instruction.Indirect{Register: 2}.Operand(), instruction.CCR.Operand()},
 			expectedString:      "ldc.w @er2, ccr",
 		},
 		{
@@ -1737,7 +1682,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.R8_LDC, // This one is very strange... Check TODO
-			expectedRegSrc:      []byte{0x02},
+			expectedOperands:    []instruction.Operand{instruction.Indirect{Register: 2}.Operand(), instruction.EXR.Operand()},
 			expectedString:      "ldc.w @er2, exr",
 		},
 		{
@@ -1748,8 +1693,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.AR32_R8,
-			expectedRegSrc:      []byte{0x07},
-			expectedRegDst:      []byte{0x09},
+			expectedOperands:    []instruction.Operand{instruction.Indirect{Register: 7}.Operand(), instruction.Register{Number: 9, Size: size.Byte}.Operand()},
 			expectedString:      "mov.b @sp, r1l",
 		},
 		{
@@ -1760,8 +1704,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.AR32_R16,
-			expectedRegSrc:      []byte{0x02},
-			expectedRegDst:      []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Indirect{Register: 2}.Operand(), instruction.Register{Number: 4, Size: size.Word}.Operand()},
 			expectedString:      "mov.w @er2, r4",
 		},
 		{
@@ -1772,8 +1715,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.AR32_R32,
-			expectedRegSrc:      []byte{0x02},
-			expectedRegDst:      []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Indirect{Register: 2}.Operand(), instruction.Register{Number: 3, Size: size.Longword}.Operand()},
 			expectedString:      "mov.l @er2, er3",
 		},
 		{
@@ -1784,8 +1726,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.R8_AR32,
-			expectedRegSrc:      []byte{0x00},
-			expectedRegDst:      []byte{0x0A},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 0, Size: size.Byte}.Operand(), instruction.Indirect{Register: 2}.Operand()},
 			expectedString:      "mov.b r0h, @er2",
 		},
 		{
@@ -1796,8 +1737,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.R16_AR32,
-			expectedRegSrc:      []byte{0x08},
-			expectedRegDst:      []byte{0x0D},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 8, Size: size.Word}.Operand(), instruction.Indirect{Register: 5}.Operand()},
 			expectedString:      "mov.w e0, @er5",
 		},
 		{
@@ -1808,8 +1748,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.R32_AR32,
-			expectedRegSrc:      []byte{0x05},
-			expectedRegDst:      []byte{0x09},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Longword}.Operand(), instruction.Indirect{Register: 1}.Operand()},
 			expectedString:      "mov.l er5, @er1",
 		},
 		{
@@ -1820,7 +1759,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.R8_STC,
-			expectedRegDst:      []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.CCR.Operand(), instruction.Indirect{Register: 7}.Operand()},
 			expectedString:      "stc.w ccr, @sp",
 		},
 		{
@@ -1831,7 +1770,7 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.R8_STC, // This one is very strange... Check TODO
-			expectedRegDst:      []byte{0x0D},
+			expectedOperands:    []instruction.Operand{instruction.EXR.Operand(), instruction.Indirect{Register: 5}.Operand()},
 			expectedString:      "stc.w exr, @er5",
 		},
 		{
@@ -1842,14 +1781,14 @@ func TestDetermineOperandTypeAndSetDataRegisterIndirect(t *testing.T) {
 				AddressingMode: addressingmode.RegisterIndirect,
 			},
 			expectedOperandType: operand.S4_R32,
+			expectedOperands:    []instruction.Operand{instruction.Indirect{Register: 4}.Operand()},
 			expectedString:      "tas @er4",
 		},
 	}
 	for _, tc := range testCases {
 		tc.instruction.DetermineOperandTypeAndSetData()
 		assert.Equal(t, tc.expectedOperandType, tc.instruction.OperandType, "expected operand type to be %s, got %s", tc.expectedOperandType, tc.instruction.OperandType)
-		assert.Equal(t, tc.expectedRegSrc, tc.instruction.RegSrc, "expected RegSrc %v, got %v", tc.expectedRegSrc, tc.instruction.RegSrc)
-		assert.Equal(t, tc.expectedRegDst, tc.instruction.RegDst, "expected RegDst %v, got %v", tc.expectedRegDst, tc.instruction.RegDst)
+		assert.Equal(t, tc.expectedOperands, tc.instruction.Operands())
 		assert.Equal(t, tc.expectedString, tc.instruction.String())
 
 	}
@@ -1859,9 +1798,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 	testCases := []struct {
 		instruction         instruction.Inst
 		expectedOperandType operand.OperandType
-		expectedRegSrc      []byte
-		expectedRegDst      []byte
-		expectedImm         []byte
+		expectedOperands    []instruction.Operand
 		expectedString      string
 	}{
 		{
@@ -1872,8 +1809,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_R8,
-			expectedRegDst:      []byte{0x0D},
-			expectedImm:         []byte{0x81},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x81, Size: size.Byte}.Operand(), instruction.Register{Number: 13, Size: size.Byte}.Operand()},
 			expectedString:      "add.b #0x81, r5l",
 		},
 		{
@@ -1884,8 +1820,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I16_R16,
-			expectedRegDst:      []byte{0x01},
-			expectedImm:         []byte{0x30, 0x39},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x3039, Size: size.Word}.Operand(), instruction.Register{Number: 1, Size: size.Word}.Operand()},
 			expectedString:      "add.w #0x3039, r1",
 		},
 		{
@@ -1896,8 +1831,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I32_R32,
-			expectedRegDst:      []byte{0x05},
-			expectedImm:         []byte{0x12, 0x34, 0x56, 0x78},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x12345678, Size: size.Longword}.Operand(), instruction.Register{Number: 5, Size: size.Longword}.Operand()},
 			expectedString:      "add.l #0x12345678, er5",
 		},
 		{
@@ -1908,8 +1842,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_R8,
-			expectedRegDst:      []byte{0x0F},
-			expectedImm:         []byte{0x01},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x1, Size: size.Byte}.Operand(), instruction.Register{Number: 15, Size: size.Byte}.Operand()},
 			expectedString:      "addx #0x01, r7l",
 		},
 		{
@@ -1920,8 +1853,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_R8,
-			expectedRegDst:      []byte{0x00},
-			expectedImm:         []byte{0x7B},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x7B, Size: size.Byte}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "and.b #0x7B, r0h",
 		},
 		{
@@ -1932,8 +1864,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I16_R16,
-			expectedRegDst:      []byte{0x0C},
-			expectedImm:         []byte{0x26, 0x94},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x2694, Size: size.Word}.Operand(), instruction.Register{Number: 12, Size: size.Word}.Operand()},
 			expectedString:      "and.w #0x2694, e4",
 		},
 		{
@@ -1944,8 +1875,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I32_R32,
-			expectedRegDst:      []byte{0x03},
-			expectedImm:         []byte{0x00, 0x0A, 0xBC, 0xDE},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0xABCDE, Size: size.Longword}.Operand(), instruction.Register{Number: 3, Size: size.Longword}.Operand()},
 			expectedString:      "and.l #0x000ABCDE, er3",
 		},
 		{
@@ -1956,7 +1886,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_CCR,
-			expectedImm:         []byte{0xC0},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0xC0, Size: size.Byte}.Operand(), instruction.CCR.Operand()},
 			expectedString:      "andc #0xC0, ccr",
 		},
 		{
@@ -1967,7 +1897,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_EXR,
-			expectedImm:         []byte{0xA5},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0xA5, Size: size.Byte}.Operand(), instruction.EXR.Operand()},
 			expectedString:      "andc #0xA5, exr",
 		},
 		{
@@ -1978,8 +1908,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_R8,
-			expectedRegDst:      []byte{0x00},
-			expectedImm:         []byte{0x00},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x0, Size: size.Byte}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "cmp.b #0x00, r0h",
 		},
 		{
@@ -1990,8 +1919,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I16_R16,
-			expectedRegDst:      []byte{0x0D},
-			expectedImm:         []byte{0x1F, 0xFF},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x1FFF, Size: size.Word}.Operand(), instruction.Register{Number: 13, Size: size.Word}.Operand()},
 			expectedString:      "cmp.w #0x1FFF, e5",
 		},
 		{
@@ -2002,8 +1930,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I32_R32,
-			expectedRegDst:      []byte{0x04},
-			expectedImm:         []byte{0x00, 0x00, 0xFF, 0xFF},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0xFFFF, Size: size.Longword}.Operand(), instruction.Register{Number: 4, Size: size.Longword}.Operand()},
 			expectedString:      "cmp.l #0x0000FFFF, er4",
 		},
 		{
@@ -2014,7 +1941,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_CCR,
-			expectedImm:         []byte{0xC1},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0xC1, Size: size.Byte}.Operand(), instruction.CCR.Operand()},
 			expectedString:      "ldc.b #0xC1, ccr",
 		},
 		{
@@ -2025,7 +1952,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_EXR,
-			expectedImm:         []byte{0x6A},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x6A, Size: size.Byte}.Operand(), instruction.EXR.Operand()},
 			expectedString:      "ldc.b #0x6A, exr",
 		},
 		{
@@ -2036,8 +1963,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_R8,
-			expectedRegDst:      []byte{0x00},
-			expectedImm:         []byte{0x1F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x1F, Size: size.Byte}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "mov.b #0x1F, r0h",
 		},
 		{
@@ -2048,8 +1974,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I16_R16,
-			expectedRegDst:      []byte{0x0B},
-			expectedImm:         []byte{0x27, 0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x270F, Size: size.Word}.Operand(), instruction.Register{Number: 11, Size: size.Word}.Operand()},
 			expectedString:      "mov.w #0x270F, e3",
 		},
 		{
@@ -2060,8 +1985,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I32_R32,
-			expectedRegDst:      []byte{0x00},
-			expectedImm:         []byte{0x00, 0x00, 0x11, 0xD7},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x11D7, Size: size.Longword}.Operand(), instruction.Register{Number: 0, Size: size.Longword}.Operand()},
 			expectedString:      "mov.l #0x000011D7, er0",
 		},
 		{
@@ -2072,8 +1996,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_R8,
-			expectedRegDst:      []byte{0x01},
-			expectedImm:         []byte{0x04},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x4, Size: size.Byte}.Operand(), instruction.Register{Number: 1, Size: size.Byte}.Operand()},
 			expectedString:      "or.b #0x04, r1h",
 		},
 		{
@@ -2084,8 +2007,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I16_R16,
-			expectedRegDst:      []byte{0x00},
-			expectedImm:         []byte{0x00, 0xC0},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0xC0, Size: size.Word}.Operand(), instruction.Register{Number: 0, Size: size.Word}.Operand()},
 			expectedString:      "or.w #0x00C0, r0",
 		},
 		{
@@ -2096,8 +2018,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I32_R32,
-			expectedRegDst:      []byte{0x00},
-			expectedImm:         []byte{0x00, 0x00, 0x00, 0xFE},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0xFE, Size: size.Longword}.Operand(), instruction.Register{Number: 0, Size: size.Longword}.Operand()},
 			expectedString:      "or.l #0x000000FE, er0",
 		},
 		{
@@ -2108,7 +2029,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_CCR,
-			expectedImm:         []byte{0x01},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x1, Size: size.Byte}.Operand(), instruction.CCR.Operand()},
 			expectedString:      "orc #0x01, ccr",
 		},
 		{
@@ -2119,7 +2040,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_EXR,
-			expectedImm:         []byte{0x7B},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x7B, Size: size.Byte}.Operand(), instruction.EXR.Operand()},
 			expectedString:      "orc #0x7B, exr",
 		},
 		{
@@ -2130,8 +2051,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I16_R16,
-			expectedRegDst:      []byte{0x0B},
-			expectedImm:         []byte{0xFF, 0xF8},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0xFFF8, Size: size.Word}.Operand(), instruction.Register{Number: 11, Size: size.Word}.Operand()},
 			expectedString:      "sub.w #0xFFF8, e3",
 		},
 		{
@@ -2142,8 +2062,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I32_R32,
-			expectedRegDst:      []byte{0x07},
-			expectedImm:         []byte{0xFF, 0xFF, 0xFF, 0xF0},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0xFFFFFFF0, Size: size.Longword}.Operand(), instruction.Register{Number: 7, Size: size.Longword}.Operand()},
 			expectedString:      "sub.l #0xFFFFFFF0, sp",
 		},
 		{
@@ -2154,8 +2073,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_R8,
-			expectedRegDst:      []byte{0x05},
-			expectedImm:         []byte{0x08},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x8, Size: size.Byte}.Operand(), instruction.Register{Number: 5, Size: size.Byte}.Operand()},
 			expectedString:      "subx #0x08, r5h",
 		},
 		{
@@ -2166,8 +2084,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_R8,
-			expectedRegDst:      []byte{0x04},
-			expectedImm:         []byte{0x80},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x80, Size: size.Byte}.Operand(), instruction.Register{Number: 4, Size: size.Byte}.Operand()},
 			expectedString:      "xor.b #0x80, r4h",
 		},
 		{
@@ -2178,8 +2095,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I16_R16,
-			expectedRegDst:      []byte{0x0D},
-			expectedImm:         []byte{0x20, 0x00},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x2000, Size: size.Word}.Operand(), instruction.Register{Number: 13, Size: size.Word}.Operand()},
 			expectedString:      "xor.w #0x2000, e5",
 		},
 		{
@@ -2190,8 +2106,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I32_R32,
-			expectedRegDst:      []byte{0x06},
-			expectedImm:         []byte{0x00, 0x00, 0xFF, 0xFF},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0xFFFF, Size: size.Longword}.Operand(), instruction.Register{Number: 6, Size: size.Longword}.Operand()},
 			expectedString:      "xor.l #0x0000FFFF, er6",
 		},
 		{
@@ -2202,7 +2117,7 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_CCR,
-			expectedImm:         []byte{0x40},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x40, Size: size.Byte}.Operand(), instruction.CCR.Operand()},
 			expectedString:      "xorc #0x40, ccr",
 		},
 		{
@@ -2213,15 +2128,14 @@ func TestDetermineOperandTypeAndSetDataRegisterImmediate(t *testing.T) {
 				AddressingMode: addressingmode.Immediate,
 			},
 			expectedOperandType: operand.I8_EXR,
-			expectedImm:         []byte{0x9E},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0x9E, Size: size.Byte}.Operand(), instruction.EXR.Operand()},
 			expectedString:      "xorc #0x9E, exr",
 		},
 	}
 	for _, tc := range testCases {
 		tc.instruction.DetermineOperandTypeAndSetData()
 		assert.Equal(t, tc.expectedOperandType, tc.instruction.OperandType, "expected operand type to be %s, got %s", tc.expectedOperandType, tc.instruction.OperandType)
-		assert.Equal(t, tc.expectedImm, tc.instruction.Imm, "expected Imm %v, got %v", tc.expectedImm, tc.instruction.Imm)
-		assert.Equal(t, tc.expectedRegDst, tc.instruction.RegDst, "expected RegDst %v, got %v", tc.expectedRegDst, tc.instruction.RegDst)
+		assert.Equal(t, tc.expectedOperands, tc.instruction.Operands())
 		assert.Equal(t, tc.expectedString, tc.instruction.String())
 
 	}
@@ -2231,11 +2145,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 	testCases := []struct {
 		instruction         instruction.Inst
 		expectedOperandType operand.OperandType
-		expectedRegSrc      []byte
-		expectedRegDst      []byte
-		expectedImm         []byte
-		expectedImmL        []byte
-		expectedImmR        []byte
+		expectedOperands    []instruction.Operand
 		expectedString      string
 	}{
 		{
@@ -2246,8 +2156,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x05},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "band #5, @0xC0:8",
 		},
 		{
@@ -2258,8 +2167,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x01, 0x23},
-			expectedImmR:        []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 3}.Operand(), instruction.Absolute{Address: 0x123, Width: 16}.Operand()},
 			expectedString:      "band #3, @0x0123:16",
 		},
 		{
@@ -2270,8 +2178,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x12, 0x34, 0x56, 0x78},
-			expectedImmR:        []byte{0x05},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Absolute{Address: 0x12345678, Width: 32}.Operand()},
 			expectedString:      "band #5, @0x12345678:32",
 		},
 		{
@@ -2282,8 +2189,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x01},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bclr #1, @0xC0:8",
 		},
 		{
@@ -2294,8 +2200,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x01, 0x23},
-			expectedImmR:        []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 3}.Operand(), instruction.Absolute{Address: 0x123, Width: 16}.Operand()},
 			expectedString:      "bclr #3, @0x0123:16",
 		},
 		{
@@ -2306,8 +2211,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x12, 0x34, 0x56, 0x78},
-			expectedImmR:        []byte{0x05},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Absolute{Address: 0x12345678, Width: 32}.Operand()},
 			expectedString:      "bclr #5, @0x12345678:32",
 		},
 		{
@@ -2318,8 +2222,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI8_BCLR,
-			expectedRegDst:      []byte{0x05},
-			expectedImm:         []byte{0xC0},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bclr r5h, @0xC0:8",
 		},
 		{
@@ -2330,8 +2233,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI16_S6,
-			expectedRegDst:      []byte{0x07},
-			expectedImm:         []byte{0x01, 0x23},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 7, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0x123, Width: 16}.Operand()},
 			expectedString:      "bclr r7h, @0x0123:16",
 		},
 		{
@@ -2342,8 +2244,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI32_BCLR,
-			expectedRegDst:      []byte{0x0E},
-			expectedImm:         []byte{0x12, 0x34, 0x56, 0x78},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 14, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0x12345678, Width: 32}.Operand()},
 			expectedString:      "bclr r6l, @0x12345678:32",
 		},
 		{
@@ -2354,8 +2255,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x08},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "biand #0, @0xC0:8",
 		},
 		{
@@ -2366,8 +2266,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x01, 0x23},
-			expectedImmR:        []byte{0x0B},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 3}.Operand(), instruction.Absolute{Address: 0x123, Width: 16}.Operand()},
 			expectedString:      "biand #3, @0x0123:16",
 		},
 		{
@@ -2378,8 +2277,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x12, 0x34, 0x67, 0x89},
-			expectedImmR:        []byte{0x0D},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Absolute{Address: 0x12346789, Width: 32}.Operand()},
 			expectedString:      "biand #5, @0x12346789:32",
 		},
 		{
@@ -2390,8 +2288,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x08},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bild #0, @0xC0:8",
 		},
 		{
@@ -2402,8 +2299,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x01, 0x23},
-			expectedImmR:        []byte{0x0B},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 3}.Operand(), instruction.Absolute{Address: 0x123, Width: 16}.Operand()},
 			expectedString:      "bild #3, @0x0123:16",
 		},
 		{
@@ -2414,8 +2310,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x12, 0x34, 0x56, 0x78},
-			expectedImmR:        []byte{0x0D},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Absolute{Address: 0x12345678, Width: 32}.Operand()},
 			expectedString:      "bild #5, @0x12345678:32",
 		},
 		{
@@ -2426,8 +2321,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x08},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bior #0, @0xC0:8",
 		},
 		{
@@ -2438,8 +2332,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 16}.Operand()},
 			expectedString:      "bior #7, @0x0000:16",
 		},
 		{
@@ -2450,8 +2343,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x00, 0x00, 0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 32}.Operand()},
 			expectedString:      "bior #7, @0x00000000:32",
 		},
 		{
@@ -2462,8 +2354,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x08},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bist #0, @0xC0:8",
 		},
 		{
@@ -2474,8 +2365,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 16}.Operand()},
 			expectedString:      "bist #7, @0x0000:16",
 		},
 		{
@@ -2486,8 +2376,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x00, 0x00, 0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 32}.Operand()},
 			expectedString:      "bist #7, @0x00000000:32",
 		},
 		{
@@ -2498,68 +2387,62 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x08},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bixor #0, @0xC0:8",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x6A, 0x30, 0x00, 0x00, 0x75, 0xF0},
+				Bytes:          []byte{0x6A, 0x10, 0x00, 0x00, 0x75, 0xF0},
 				Opcode:         opcode.Bixor,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 16}.Operand()},
 			expectedString:      "bixor #7, @0x0000:16",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x6A, 0x38, 0x00, 0x00, 0x00, 0x00, 0x75, 0xF0},
+				Bytes:          []byte{0x6A, 0x30, 0x00, 0x00, 0x00, 0x00, 0x75, 0xF0},
 				Opcode:         opcode.Bixor,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x00, 0x00, 0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 32}.Operand()},
 			expectedString:      "bixor #7, @0x00000000:32",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x7E, 0xC0, 0x77, 0x80},
+				Bytes:          []byte{0x7E, 0xC0, 0x77, 0x00},
 				Opcode:         opcode.Bld,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x08},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bld #0, @0xC0:8",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x6A, 0x30, 0x00, 0x00, 0x77, 0xF0},
+				Bytes:          []byte{0x6A, 0x10, 0x00, 0x00, 0x77, 0x70},
 				Opcode:         opcode.Bld,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 16}.Operand()},
 			expectedString:      "bld #7, @0x0000:16",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x6A, 0x38, 0x00, 0x00, 0x00, 0x00, 0x77, 0xF0},
+				Bytes:          []byte{0x6A, 0x30, 0x00, 0x00, 0x00, 0x00, 0x77, 0x70},
 				Opcode:         opcode.Bld,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x00, 0x00, 0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 32}.Operand()},
 			expectedString:      "bld #7, @0x00000000:32",
 		},
 		{
@@ -2570,8 +2453,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x01},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bnot #1, @0xC0:8",
 		},
 		{
@@ -2582,8 +2464,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x01, 0x23},
-			expectedImmR:        []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 3}.Operand(), instruction.Absolute{Address: 0x123, Width: 16}.Operand()},
 			expectedString:      "bnot #3, @0x0123:16",
 		},
 		{
@@ -2594,8 +2475,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x12, 0x34, 0x56, 0x78},
-			expectedImmR:        []byte{0x05},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Absolute{Address: 0x12345678, Width: 32}.Operand()},
 			expectedString:      "bnot #5, @0x12345678:32",
 		},
 		{
@@ -2606,8 +2486,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI8_BCLR,
-			expectedRegDst:      []byte{0x05},
-			expectedImm:         []byte{0xC0},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bnot r5h, @0xC0:8",
 		},
 		{
@@ -2618,8 +2497,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI16_S6,
-			expectedRegDst:      []byte{0x07},
-			expectedImm:         []byte{0x01, 0x23},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 7, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0x123, Width: 16}.Operand()},
 			expectedString:      "bnot r7h, @0x0123:16",
 		},
 		{
@@ -2631,44 +2509,40 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 			},
 			// TODO: Terrible operand type name.
 			expectedOperandType: operand.R8_AI32_BCLR,
-			expectedRegDst:      []byte{0x0E},
-			expectedImm:         []byte{0x12, 0x34, 0x56, 0x78},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 14, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0x12345678, Width: 32}.Operand()},
 			expectedString:      "bnot r6l, @0x12345678:32",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x7E, 0xC0, 0x74, 0x80},
+				Bytes:          []byte{0x7E, 0xC0, 0x74, 0x00},
 				Opcode:         opcode.Bor,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x08},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bor #0, @0xC0:8",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x6A, 0x10, 0x00, 0x00, 0x74, 0xF0},
+				Bytes:          []byte{0x6A, 0x10, 0x00, 0x00, 0x74, 0x70},
 				Opcode:         opcode.Bor,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 16}.Operand()},
 			expectedString:      "bor #7, @0x0000:16",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x6A, 0x30, 0x00, 0x00, 0x00, 0x00, 0x74, 0xF0},
+				Bytes:          []byte{0x6A, 0x30, 0x00, 0x00, 0x00, 0x00, 0x74, 0x70},
 				Opcode:         opcode.Bor,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x00, 0x00, 0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 32}.Operand()},
 			expectedString:      "bor #7, @0x00000000:32",
 		},
 		{
@@ -2679,8 +2553,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x01},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bset #1, @0xC0:8",
 		},
 		{
@@ -2691,8 +2564,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x01, 0x23},
-			expectedImmR:        []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 3}.Operand(), instruction.Absolute{Address: 0x123, Width: 16}.Operand()},
 			expectedString:      "bset #3, @0x0123:16",
 		},
 		{
@@ -2703,8 +2575,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x12, 0x34, 0x56, 0x78},
-			expectedImmR:        []byte{0x05},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Absolute{Address: 0x12345678, Width: 32}.Operand()},
 			expectedString:      "bset #5, @0x12345678:32",
 		},
 		{
@@ -2715,8 +2586,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI8_BCLR,
-			expectedRegDst:      []byte{0x05},
-			expectedImm:         []byte{0xC0},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bset r5h, @0xC0:8",
 		},
 		{
@@ -2727,8 +2597,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI16_S6,
-			expectedRegDst:      []byte{0x07},
-			expectedImm:         []byte{0x01, 0x23},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 7, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0x123, Width: 16}.Operand()},
 			expectedString:      "bset r7h, @0x0123:16",
 		},
 		{
@@ -2740,44 +2609,40 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 			},
 			// TODO: Terrible operand type name.
 			expectedOperandType: operand.R8_AI32_BCLR,
-			expectedRegDst:      []byte{0x0E},
-			expectedImm:         []byte{0x12, 0x34, 0x56, 0x78},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 14, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0x12345678, Width: 32}.Operand()},
 			expectedString:      "bset r6l, @0x12345678:32",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x7F, 0xC0, 0x67, 0x80},
+				Bytes:          []byte{0x7F, 0xC0, 0x67, 0x00},
 				Opcode:         opcode.Bst,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x08},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bst #0, @0xC0:8",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x6A, 0x18, 0x00, 0x00, 0x67, 0xF0},
+				Bytes:          []byte{0x6A, 0x18, 0x00, 0x00, 0x67, 0x70},
 				Opcode:         opcode.Bst,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 16}.Operand()},
 			expectedString:      "bst #7, @0x0000:16",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x6A, 0x38, 0x00, 0x00, 0x00, 0x00, 0x67, 0xF0},
+				Bytes:          []byte{0x6A, 0x38, 0x00, 0x00, 0x00, 0x00, 0x67, 0x70},
 				Opcode:         opcode.Bst,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x00, 0x00, 0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 32}.Operand()},
 			expectedString:      "bst #7, @0x00000000:32",
 		},
 		{
@@ -2788,8 +2653,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x01},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 1}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "btst #1, @0xC0:8",
 		},
 		{
@@ -2800,8 +2664,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x01, 0x23},
-			expectedImmR:        []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 3}.Operand(), instruction.Absolute{Address: 0x123, Width: 16}.Operand()},
 			expectedString:      "btst #3, @0x0123:16",
 		},
 		{
@@ -2812,8 +2675,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x12, 0x34, 0x56, 0x78},
-			expectedImmR:        []byte{0x05},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 5}.Operand(), instruction.Absolute{Address: 0x12345678, Width: 32}.Operand()},
 			expectedString:      "btst #5, @0x12345678:32",
 		},
 		{
@@ -2824,8 +2686,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI8_BCLR,
-			expectedRegDst:      []byte{0x05},
-			expectedImm:         []byte{0xC0},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "btst r5h, @0xC0:8",
 		},
 		{
@@ -2836,8 +2697,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI16_S6,
-			expectedRegDst:      []byte{0x07},
-			expectedImm:         []byte{0x01, 0x23},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 7, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0x123, Width: 16}.Operand()},
 			expectedString:      "btst r7h, @0x0123:16",
 		},
 		{
@@ -2849,44 +2709,40 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 			},
 			// TODO: Terrible operand type name.
 			expectedOperandType: operand.R8_AI32_BCLR,
-			expectedRegDst:      []byte{0x0E},
-			expectedImm:         []byte{0x12, 0x34, 0x56, 0x78},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 14, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0x12345678, Width: 32}.Operand()},
 			expectedString:      "btst r6l, @0x12345678:32",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x7E, 0xC0, 0x75, 0x80},
+				Bytes:          []byte{0x7E, 0xC0, 0x75, 0x00},
 				Opcode:         opcode.Bxor,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI8,
-			expectedImmL:        []byte{0xC0},
-			expectedImmR:        []byte{0x08},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 0}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "bxor #0, @0xC0:8",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x6A, 0x10, 0x00, 0x00, 0x75, 0xF0},
+				Bytes:          []byte{0x6A, 0x10, 0x00, 0x00, 0x75, 0x70},
 				Opcode:         opcode.Bxor,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI16,
-			expectedImmL:        []byte{0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 16}.Operand()},
 			expectedString:      "bxor #7, @0x0000:16",
 		},
 		{
 			instruction: instruction.Inst{
-				Bytes:          []byte{0x6A, 0x30, 0x00, 0x00, 0x00, 0x00, 0x75, 0xF0},
+				Bytes:          []byte{0x6A, 0x30, 0x00, 0x00, 0x00, 0x00, 0x75, 0x70},
 				Opcode:         opcode.Bxor,
 				BWL:            size.Unset,
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.Ix_AI32,
-			expectedImmL:        []byte{0x00, 0x00, 0x00, 0x00},
-			expectedImmR:        []byte{0x0F},
+			expectedOperands:    []instruction.Operand{instruction.Immediate{Value: 7}.Operand(), instruction.Absolute{Address: 0x0, Width: 32}.Operand()},
 			expectedString:      "bxor #7, @0x00000000:32",
 		},
 		{
@@ -2897,7 +2753,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.I24,
-			expectedImm:         []byte{0x12, 0x89, 0xDE},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x1289DE, Width: 24}.Operand()},
 			expectedString:      "jmp @0x1289DE:24",
 		},
 		{
@@ -2908,7 +2764,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.I24,
-			expectedImm:         []byte{0x12, 0x89, 0xDE},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x1289DE, Width: 24}.Operand()},
 			expectedString:      "jsr @0x1289DE:24",
 		},
 		{
@@ -2919,7 +2775,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI16_CCR,
-			expectedImm:         []byte{0x01, 0x26},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x126, Width: 16}.Operand(), instruction.CCR.Operand()},
 			expectedString:      "ldc.w @0x0126:16, ccr",
 		},
 		{
@@ -2930,7 +2786,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI32_CCR,
-			expectedImm:         []byte{0x00, 0x12, 0x89, 0xDE},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x1289DE, Width: 32}.Operand(), instruction.CCR.Operand()},
 			expectedString:      "ldc.w @0x001289DE:32, ccr",
 		},
 		{
@@ -2941,7 +2797,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI16_CCR,
-			expectedImm:         []byte{0x01, 0x26},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x126, Width: 16}.Operand(), instruction.EXR.Operand()},
 			expectedString:      "ldc.w @0x0126:16, exr",
 		},
 		{
@@ -2952,7 +2808,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI32_CCR,
-			expectedImm:         []byte{0x00, 0x12, 0x89, 0xDE},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x1289DE, Width: 32}.Operand(), instruction.EXR.Operand()},
 			expectedString:      "ldc.w @0x001289DE:32, exr",
 		},
 		{
@@ -2963,8 +2819,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI8_R8,
-			expectedImm:         []byte{0xFF},
-			expectedRegDst:      []byte{0x00},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0xFF, Width: 8}.Operand(), instruction.Register{Number: 0, Size: size.Byte}.Operand()},
 			expectedString:      "mov.b @0xFF:8, r0h",
 		},
 		{
@@ -2975,8 +2830,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI16_R8,
-			expectedImm:         []byte{0x01, 0x26},
-			expectedRegDst:      []byte{0x0C},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x126, Width: 16}.Operand(), instruction.Register{Number: 12, Size: size.Byte}.Operand()},
 			expectedString:      "mov.b @0x0126:16, r4l",
 		},
 		{
@@ -2987,8 +2841,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI32_R8,
-			expectedImm:         []byte{0x00, 0x12, 0x89, 0xDE},
-			expectedRegDst:      []byte{0x0A},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x1289DE, Width: 32}.Operand(), instruction.Register{Number: 10, Size: size.Byte}.Operand()},
 			expectedString:      "mov.b @0x001289DE:32, r2l",
 		},
 		{
@@ -2999,8 +2852,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI16_R16,
-			expectedImm:         []byte{0x01, 0x26},
-			expectedRegDst:      []byte{0x0E},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x126, Width: 16}.Operand(), instruction.Register{Number: 14, Size: size.Word}.Operand()},
 			expectedString:      "mov.w @0x0126:16, e6",
 		},
 		{
@@ -3011,8 +2863,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI32_R16,
-			expectedImm:         []byte{0x00, 0x12, 0x89, 0xDE},
-			expectedRegDst:      []byte{0x05},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x1289DE, Width: 32}.Operand(), instruction.Register{Number: 5, Size: size.Word}.Operand()},
 			expectedString:      "mov.w @0x001289DE:32, r5",
 		},
 		{
@@ -3023,8 +2874,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI16_R32,
-			expectedImm:         []byte{0x01, 0x26},
-			expectedRegDst:      []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x126, Width: 16}.Operand(), instruction.Register{Number: 3, Size: size.Longword}.Operand()},
 			expectedString:      "mov.l @0x0126:16, er3",
 		},
 		{
@@ -3035,8 +2885,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI32_R32,
-			expectedImm:         []byte{0x00, 0x12, 0x89, 0xDE},
-			expectedRegDst:      []byte{0x02},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0x1289DE, Width: 32}.Operand(), instruction.Register{Number: 2, Size: size.Longword}.Operand()},
 			expectedString:      "mov.l @0x001289DE:32, er2",
 		},
 		{
@@ -3047,8 +2896,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI8,
-			expectedImm:         []byte{0xC0},
-			expectedRegSrc:      []byte{0x03},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 1, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0xC0, Width: 8}.Operand()},
 			expectedString:      "mov.b r1h, @0xC0:8",
 		},
 		{
@@ -3059,8 +2907,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI16,
-			expectedImm:         []byte{0x01, 0x26},
-			expectedRegSrc:      []byte{0x09},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 9, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0x126, Width: 16}.Operand()},
 			expectedString:      "mov.b r1l, @0x0126:16",
 		},
 		{
@@ -3071,8 +2918,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI32,
-			expectedImm:         []byte{0x00, 0x12, 0x89, 0xDE},
-			expectedRegSrc:      []byte{0x02},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 2, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0x1289DE, Width: 32}.Operand()},
 			expectedString:      "mov.b r2h, @0x001289DE:32",
 		},
 		{
@@ -3083,8 +2929,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R16_AI16,
-			expectedImm:         []byte{0x01, 0x26},
-			expectedRegSrc:      []byte{0x08},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 8, Size: size.Word}.Operand(), instruction.Absolute{Address: 0x126, Width: 16}.Operand()},
 			expectedString:      "mov.w e0, @0x0126:16",
 		},
 		{
@@ -3095,8 +2940,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R16_AI32,
-			expectedImm:         []byte{0x00, 0x12, 0x89, 0xDE},
-			expectedRegSrc:      []byte{0x0C},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 12, Size: size.Word}.Operand(), instruction.Absolute{Address: 0x1289DE, Width: 32}.Operand()},
 			expectedString:      "mov.w e4, @0x001289DE:32",
 		},
 		{
@@ -3107,8 +2951,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R32_AI16,
-			expectedImm:         []byte{0x01, 0x26},
-			expectedRegSrc:      []byte{0x01},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 1, Size: size.Longword}.Operand(), instruction.Absolute{Address: 0x126, Width: 16}.Operand()},
 			expectedString:      "mov.l er1, @0x0126:16",
 		},
 		{
@@ -3119,8 +2962,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R32_AI32,
-			expectedImm:         []byte{0x00, 0x12, 0x89, 0xDE},
-			expectedRegSrc:      []byte{0x02},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 2, Size: size.Longword}.Operand(), instruction.Absolute{Address: 0x1289DE, Width: 32}.Operand()},
 			expectedString:      "mov.l er2, @0x001289DE:32",
 		},
 		//
@@ -3132,8 +2974,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.AI16_R8,
-			expectedImm:         []byte{0xFF, 0xC0},
-			expectedRegSrc:      []byte{0x0D},
+			expectedOperands:    []instruction.Operand{instruction.Absolute{Address: 0xFFC0, Width: 16}.Operand(), instruction.Register{Number: 13, Size: size.Byte}.Operand()},
 			expectedString:      "movfpe @0xFFC0:16, r5l",
 		},
 		{
@@ -3144,8 +2985,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.R8_AI16,
-			expectedImm:         []byte{0xFF, 0xC0},
-			expectedRegSrc:      []byte{0x05},
+			expectedOperands:    []instruction.Operand{instruction.Register{Number: 5, Size: size.Byte}.Operand(), instruction.Absolute{Address: 0xFFC0, Width: 16}.Operand()},
 			expectedString:      "movtpe r5h, @0xFFC0:16",
 		},
 		{
@@ -3156,7 +2996,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.CCR_AI16,
-			expectedImm:         []byte{0x01, 0x26},
+			expectedOperands:    []instruction.Operand{instruction.CCR.Operand(), instruction.Absolute{Address: 0x126, Width: 16}.Operand()},
 			expectedString:      "stc.w ccr, @0x0126:16",
 		},
 		{
@@ -3167,7 +3007,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.CCR_AI32,
-			expectedImm:         []byte{0x00, 0x12, 0x89, 0xDE},
+			expectedOperands:    []instruction.Operand{instruction.CCR.Operand(), instruction.Absolute{Address: 0x1289DE, Width: 32}.Operand()},
 			expectedString:      "stc.w ccr, @0x001289DE:32",
 		},
 		{
@@ -3178,7 +3018,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.CCR_AI16,
-			expectedImm:         []byte{0x01, 0x26},
+			expectedOperands:    []instruction.Operand{instruction.EXR.Operand(), instruction.Absolute{Address: 0x126, Width: 16}.Operand()},
 			expectedString:      "stc.w exr, @0x0126:16",
 		},
 		{
@@ -3189,7 +3029,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 				AddressingMode: addressingmode.AbsoluteAddress,
 			},
 			expectedOperandType: operand.CCR_AI32,
-			expectedImm:         []byte{0x00, 0x12, 0x89, 0xDE},
+			expectedOperands:    []instruction.Operand{instruction.EXR.Operand(), instruction.Absolute{Address: 0x1289DE, Width: 32}.Operand()},
 			expectedString:      "stc.w exr, @0x001289DE:32",
 		},
 	}
@@ -3197,9 +3037,7 @@ func TestDetermineOperandTypeAndSetDataRegisterAbsoluteAddress(t *testing.T) {
 		tc.instruction.DetermineOperandTypeAndSetData()
 		fmt.Printf("%+v", tc.instruction)
 		assert.Equal(t, tc.expectedOperandType, tc.instruction.OperandType, "expected operand type to be %s, got %s", tc.expectedOperandType, tc.instruction.OperandType)
-		assert.Equal(t, tc.expectedImmL, tc.instruction.ImmL, "expected ImmL %v, got %v", tc.expectedImmL, tc.instruction.ImmL)
-		assert.Equal(t, tc.expectedImmR, tc.instruction.ImmR, "expected ImmR %v, got %v", tc.expectedImmR, tc.instruction.ImmR)
-		assert.Equal(t, tc.expectedImm, tc.instruction.Imm, "expected Imm %v, got %v", tc.expectedImm, tc.instruction.Imm)
+		assert.Equal(t, tc.expectedOperands, tc.instruction.Operands())
 		assert.Equal(t, tc.expectedString, tc.instruction.String())
 	}
 }
@@ -3208,7 +3046,7 @@ func TestDetermineOperandAndTypeAndSetDataRegisterProgramCounterRelative(t *test
 	testCases := []struct {
 		instruction         instruction.Inst
 		expectedOperandType operand.OperandType
-		expectedImm         []byte
+		expectedOperands    []instruction.Operand
 		expectedString      string
 	}{
 		{
@@ -3220,8 +3058,8 @@ func TestDetermineOperandAndTypeAndSetDataRegisterProgramCounterRelative(t *test
 				Pos:            60, // 0x3C
 			},
 			expectedOperandType: operand.O8,
+			expectedOperands:    []instruction.Operand{instruction.PCRelative{Offset: -2, Width: 8, Target: 0x3C}.Operand()},
 			expectedString:      "bra 0x0000003C:8",
-			expectedImm:         []byte{0xFE},
 		},
 		{
 			instruction: instruction.Inst{
@@ -3232,8 +3070,8 @@ func TestDetermineOperandAndTypeAndSetDataRegisterProgramCounterRelative(t *test
 				Pos:            62, // 0x3E
 			},
 			expectedOperandType: operand.O8,
+			expectedOperands:    []instruction.Operand{instruction.PCRelative{Offset: -4, Width: 8, Target: 0x3C}.Operand()},
 			expectedString:      "brn 0x0000003C:8",
-			expectedImm:         []byte{0xFC},
 		},
 		{
 			instruction: instruction.Inst{
@@ -3244,8 +3082,8 @@ func TestDetermineOperandAndTypeAndSetDataRegisterProgramCounterRelative(t *test
 				Pos:            64, // 0x40
 			},
 			expectedOperandType: operand.O8,
+			expectedOperands:    []instruction.Operand{instruction.PCRelative{Offset: -6, Width: 8, Target: 0x3C}.Operand()},
 			expectedString:      "bhi 0x0000003C:8",
-			expectedImm:         []byte{0xFA},
 		},
 		{
 			instruction: instruction.Inst{
@@ -3256,8 +3094,8 @@ func TestDetermineOperandAndTypeAndSetDataRegisterProgramCounterRelative(t *test
 				Pos:            92, // 0x5C
 			},
 			expectedOperandType: operand.O16,
+			expectedOperands:    []instruction.Operand{instruction.PCRelative{Offset: 60, Width: 16, Target: 0x9C}.Operand()},
 			expectedString:      "bra 0x0000009C:16",
-			expectedImm:         []byte{0x00, 0x3C},
 		},
 		{
 			instruction: instruction.Inst{
@@ -3268,8 +3106,8 @@ func TestDetermineOperandAndTypeAndSetDataRegisterProgramCounterRelative(t *test
 				Pos:            96, // 0x60
 			},
 			expectedOperandType: operand.O16,
+			expectedOperands:    []instruction.Operand{instruction.PCRelative{Offset: 56, Width: 16, Target: 0x9C}.Operand()},
 			expectedString:      "brn 0x0000009C:16",
-			expectedImm:         []byte{0x00, 0x38},
 		},
 		{
 			instruction: instruction.Inst{
@@ -3280,15 +3118,15 @@ func TestDetermineOperandAndTypeAndSetDataRegisterProgramCounterRelative(t *test
 				Pos:            100, // 0x64
 			},
 			expectedOperandType: operand.O16,
+			expectedOperands:    []instruction.Operand{instruction.PCRelative{Offset: 52, Width: 16, Target: 0x9C}.Operand()},
 			expectedString:      "bhi 0x0000009C:16",
-			expectedImm:         []byte{0x00, 0x34},
 		},
 	}
 	for _, tc := range testCases {
 		tc.instruction.DetermineOperandTypeAndSetData()
 		fmt.Printf("%+v", tc.instruction)
 		assert.Equal(t, tc.expectedOperandType, tc.instruction.OperandType, "expected operand type to be %s, got %s", tc.expectedOperandType, tc.instruction.OperandType)
-		assert.Equal(t, tc.expectedImm, tc.instruction.Imm, "expected Imm %v, got %v", tc.expectedImm, tc.instruction.Imm)
+		assert.Equal(t, tc.expectedOperands, tc.instruction.Operands())
 		assert.Equal(t, tc.expectedString, tc.instruction.String())
 	}
 }
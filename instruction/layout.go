@@ -0,0 +1,328 @@
+package instruction
+
+import (
+	"github.com/kn100/cybemu/addressingmode"
+	"github.com/kn100/cybemu/operand"
+	"github.com/kn100/cybemu/size"
+)
+
+// slotKind is the kind of Operand a slot makes.
+type slotKind uint8
+
+const (
+	register slotKind = iota
+	immediate
+	bit
+	absolute
+	memory
+	displacement
+	pcRelative
+	memoryIndirect
+	control
+	registerList
+	stackPointer
+)
+
+// slot says how one operand is made from the fields of a form. Displacements
+// are always in imm, and control registers in ctl.
+type slot struct {
+	kind slotKind
+	role role
+	// The size of a register or immediate. Where bwl is set, the size of the
+	// instruction is used instead, unless it is unset.
+	size size.Size
+	bwl  bool
+	// The width in bits of an absolute address, displacement or branch.
+	width int
+}
+
+func r8(r role) slot          { return slot{kind: register, role: r, size: size.Byte} }
+func r16(r role) slot         { return slot{kind: register, role: r, size: size.Word} }
+func r32(r role) slot         { return slot{kind: register, role: r, size: size.Longword} }
+func rBWL(r role) slot        { return slot{kind: register, role: r, bwl: true} }
+func i8(r role) slot          { return slot{kind: immediate, role: r, size: size.Byte} }
+func i16(r role) slot         { return slot{kind: immediate, role: r, size: size.Word} }
+func i32(r role) slot         { return slot{kind: immediate, role: r, size: size.Longword} }
+func small(r role) slot       { return slot{kind: immediate, role: r} }
+func bitNumber(r role) slot   { return slot{kind: bit, role: r} }
+func abs(r role, w int) slot  { return slot{kind: absolute, role: r, width: w} }
+func mem(r role) slot         { return slot{kind: memory, role: r} }
+func disp(r role, w int) slot { return slot{kind: displacement, role: r, width: w} }
+func pcRel(w int) slot        { return slot{kind: pcRelative, role: imm, width: w} }
+func memIndirect() slot       { return slot{kind: memoryIndirect, role: imm} }
+func ccrOrExr() slot          { return slot{kind: control, role: ctl} }
+
+// list is the registers of ldm or stm. The register field holds the last of
+// them for ldm and the first for stm, and imm holds how many follow the first.
+func list(r role) slot { return slot{kind: registerList, role: r} }
+
+// stack is @sp+ or @-sp, as the addressing mode says.
+func stack() slot { return slot{kind: stackPointer, role: reg} }
+
+// rBWLOrByte is a register of the instruction's size, or a byte register if
+// it has none.
+func rBWLOrByte(r role) slot { return slot{kind: register, role: r, size: size.Byte, bwl: true} }
+
+// memOrReg is a memory operand, or for register direct instructions, a
+// register of the instruction's size.
+func memOrReg(r role) slot { return slot{kind: memory, role: r, bwl: true} }
+
+// layouts gives the operands of each operand type, in the order they are
+// written.
+var layouts = map[operand.OperandType][]slot{
+	operand.None: {},
+
+	operand.R8_R8:               {rBWLOrByte(regSrc), rBWLOrByte(regDst)},
+	operand.R16_R16:             {rBWLOrByte(regSrc), rBWLOrByte(regDst)},
+	operand.R32_R32_S2:          {rBWL(regSrc), rBWL(regDst)},
+	operand.R32_R32_S4:          {rBWL(regSrc), rBWL(regDst)},
+	operand.R8_R16:              {rBWL(regSrc), r16(regDst)},
+	operand.R8_R16_MULXS_DIVXS:  {rBWL(regSrc), r16(regDst)},
+	operand.R16_R32:             {rBWL(regSrc), r32(regDst)},
+	operand.R16_R32_MULXS_DIVXS: {rBWL(regSrc), r32(regDst)},
+	operand.R8:                  {r8(regDst)},
+	operand.R16:                 {rBWL(regDst)},
+	operand.R32:                 {rBWL(regDst)},
+
+	operand.Ix_R32_ADDS_SUBS: {small(imm), r32(regDst)},
+	operand.Ix_R8:            {bitNumber(imm), r8(regDst)},
+	operand.Ix_R8_INC_DEC:    {small(imm), r8(regDst)},
+	operand.Ix_R8_SH:         {small(imm), r8(regDst)},
+	operand.Ix_R16_INC_DEC:   {small(imm), r16(regDst)},
+	operand.Ix_R16_SH:        {small(imm), r16(regDst)},
+	operand.Ix_R32_INC_DEC:   {small(imm), r32(regDst)},
+	operand.Ix_R32_SH:        {small(imm), r32(regDst)},
+	operand.TRAPA_Ix:         {small(imm)},
+
+	operand.I8_R8:   {i8(imm), r8(regDst)},
+	operand.I16_R16: {i16(imm), r16(regDst)},
+	operand.I32_R32: {i32(imm), r32(regDst)},
+	operand.I8_CCR:  {i8(imm), ccrOrExr()},
+	operand.I8_EXR:  {i8(imm), ccrOrExr()},
+
+	// ldc and stc use these for register direct as well as memory operands.
+	operand.R8_LDC:   {memOrReg(regSrc), ccrOrExr()},
+	operand.R8_STC:   {ccrOrExr(), memOrReg(regDst)},
+	operand.CCR_AR32: {ccrOrExr(), mem(regDst)},
+
+	operand.Ix_AR32:      {bitNumber(imm), mem(regDst)},
+	operand.AR32_S2:      {mem(regDst)},
+	operand.S4_R32:       {mem(reg)},
+	operand.AR32_R8:      {mem(regSrc), rBWL(regDst)},
+	operand.AR32_R16:     {mem(regSrc), rBWL(regDst)},
+	operand.AR32_R32:     {mem(regSrc), rBWL(regDst)},
+	operand.R8_AR32:      {rBWLOrByte(regSrc), mem(regDst)},
+	operand.R16_AR32:     {rBWLOrByte(regSrc), mem(regDst)},
+	operand.R32_AR32:     {rBWLOrByte(regSrc), mem(regDst)},
+	operand.R8_AR32_BCLR: {r8(regSrc), mem(regDst)},
+
+	operand.AI16R32_R8:  {disp(regSrc, 16), r8(regDst)},
+	operand.AI16R32_R16: {disp(regSrc, 16), r16(regDst)},
+	operand.AI16R32_R32: {disp(regSrc, 16), r32(regDst)},
+	operand.AI32R32_R8:  {disp(regSrc, 32), r8(regDst)},
+	operand.AI32R32_R16: {disp(regSrc, 32), r16(regDst)},
+	operand.AI32R32_R32: {disp(regSrc, 32), r32(regDst)},
+	operand.R8_AI16R32:  {r8(regSrc), disp(regDst, 16)},
+	operand.R16_AI16R32: {r16(regSrc), disp(regDst, 16)},
+	operand.R32_AI16R32: {r32(regSrc), disp(regDst, 16)},
+	operand.R8_AI32R32:  {r8(regSrc), disp(regDst, 32)},
+	operand.R16_AI32R32: {r16(regSrc), disp(regDst, 32)},
+	operand.R32_AI32R32: {r32(regSrc), disp(regDst, 32)},
+	operand.AI16R32_CCR: {disp(regSrc, 16), ccrOrExr()},
+	operand.AI32R32_CCR: {disp(regSrc, 32), ccrOrExr()},
+	operand.CCR_AI16R32: {ccrOrExr(), disp(regDst, 16)},
+	operand.CCR_AI32R32: {ccrOrExr(), disp(regDst, 32)},
+
+	operand.Ix_AI8:       {bitNumber(immR), abs(immL, 8)},
+	operand.Ix_AI16:      {bitNumber(immR), abs(immL, 16)},
+	operand.Ix_AI32:      {bitNumber(immR), abs(immL, 32)},
+	operand.R8_AI8_BCLR:  {r8(regDst), abs(imm, 8)},
+	operand.R8_AI16_S6:   {r8(regDst), abs(imm, 16)},
+	operand.R8_AI32_BCLR: {r8(regDst), abs(imm, 32)},
+	operand.I24:          {abs(imm, 24)},
+	operand.AI16_CCR:     {abs(imm, 16), ccrOrExr()},
+	operand.AI32_CCR:     {abs(imm, 32), ccrOrExr()},
+	operand.CCR_AI16:     {ccrOrExr(), abs(imm, 16)},
+	operand.CCR_AI32:     {ccrOrExr(), abs(imm, 32)},
+	operand.AI8_R8:       {abs(imm, 8), r8(regDst)},
+	operand.AI16_R8:      {abs(imm, 16), r8(regDst)},
+	operand.AI32_R8:      {abs(imm, 32), r8(regDst)},
+	operand.AI16_R16:     {abs(imm, 16), r16(regDst)},
+	operand.AI32_R16:     {abs(imm, 32), r16(regDst)},
+	operand.AI16_R32:     {abs(imm, 16), r32(regDst)},
+	operand.AI32_R32:     {abs(imm, 32), r32(regDst)},
+	operand.R8_AI8:       {r8(regSrc), abs(imm, 8)},
+	operand.R8_AI16:      {r8(regSrc), abs(imm, 16)},
+	operand.R8_AI32:      {r8(regSrc), abs(imm, 32)},
+	operand.R16_AI16:     {r16(regSrc), abs(imm, 16)},
+	operand.R16_AI32:     {r16(regSrc), abs(imm, 32)},
+	operand.R32_AI16:     {r32(regSrc), abs(imm, 16)},
+	operand.R32_AI32:     {r32(regSrc), abs(imm, 32)},
+
+	// S2_IMM isn't used by the table. It is a 2 byte instruction with an
+	// immediate operand.
+	operand.S2_IMM: {i8(imm)},
+
+	operand.AR32_R32R32: {stack(), list(regDst)},
+	operand.R32R32_AR32: {list(regSrc), stack()},
+
+	operand.O8:   {pcRel(8)},
+	operand.O16:  {pcRel(16)},
+	operand.AAI8: {memIndirect()},
+}
+
+// sizeOf returns the size of the register or immediate s makes in i.
+func (s slot) sizeOf(i *Inst) size.Size {
+	if s.bwl && i.BWL != size.Unset {
+		return i.BWL
+	}
+	return s.size
+}
+
+// make returns the operand s makes from the fields of f in i.
+func (s slot) make(f *form, i *Inst) Operand {
+	v := f.get(s.role, i.Bytes)
+	switch s.kind {
+	case register:
+		return registerOperand(uint8(v), s.sizeOf(i))
+	case immediate:
+		return Immediate{Value: v, Size: s.size}.Operand()
+	case bit:
+		return Immediate{Value: v & 7}.Operand()
+	case absolute:
+		return Absolute{Address: v, Width: s.width}.Operand()
+	case memory:
+		n := uint8(v & 7)
+		switch i.AddressingMode {
+		case addressingmode.RegisterDirect:
+			return registerOperand(uint8(v), s.sizeOf(i))
+		case addressingmode.RegisterIndirectWithPostIncrement:
+			return PostInc{Register: n}.Operand()
+		case addressingmode.RegisterIndirectWithPreDecrement:
+			return PreDec{Register: n}.Operand()
+		}
+		return Indirect{Register: n}.Operand()
+	case displacement:
+		d := f.get(imm, i.Bytes)
+		if s.width == 16 {
+			return Displacement{Register: uint8(v & 7), Displacement: int32(int16(d)), Width: 16}.Operand()
+		}
+		return Displacement{Register: uint8(v & 7), Displacement: int32(d), Width: 32}.Operand()
+	case pcRelative:
+		offset := int32(int8(v))
+		if s.width == 16 {
+			offset = int32(int16(v))
+		}
+		return PCRelative{Offset: offset, Width: s.width, Target: i.Pos + len(i.Bytes) + int(offset)}.Operand()
+	case memoryIndirect:
+		return MemoryIndirect{Address: uint8(v)}.Operand()
+	case registerList:
+		l := RegisterList{First: uint8(v & 7), Count: uint8(f.get(imm, i.Bytes)) + 1}
+		if s.role == regDst {
+			l.First -= l.Count - 1
+		}
+		return l.Operand()
+	case stackPointer:
+		if i.AddressingMode == addressingmode.RegisterIndirectWithPreDecrement {
+			return PreDec{Register: 7}.Operand()
+		}
+		return PostInc{Register: 7}.Operand()
+	default:
+		return ControlRegister(v).Operand()
+	}
+}
+
+// registerOperand returns register n of size s. Longword registers are 3 bits,
+// with the bit above being part of the encoding of the instruction.
+func registerOperand(n uint8, s size.Size) Operand {
+	if s == size.Longword {
+		n &= 7
+	}
+	return Register{Number: n, Size: s}.Operand()
+}
+
+// value returns what has to be put in the field of a form for s to make op in
+// i. Displacements and register lists take two fields, and d is what goes in
+// the second, which is always imm.
+func (s slot) value(op Operand, i *Inst) (v uint32, d uint32, ok bool) {
+	switch s.kind {
+	case register:
+		r, ok := op.Register()
+		return uint32(r.Number), 0, ok && r.Size == s.sizeOf(i) && registerFits(r)
+	case immediate:
+		n, ok := op.Immediate()
+		return n.Value, 0, ok && n.Size == s.size
+	case bit:
+		n, ok := op.Immediate()
+		return n.Value, 0, ok && n.Size == size.Unset && n.Value < 8
+	case absolute:
+		a, ok := op.Absolute()
+		return a.Address, 0, ok && a.Width == s.width
+	case memory:
+		switch op.Kind() {
+		case RegisterOperand:
+			r, _ := op.Register()
+			return uint32(r.Number), 0, i.AddressingMode == addressingmode.RegisterDirect && r.Size == s.sizeOf(i) && registerFits(r)
+		case PostIncOperand:
+			p, _ := op.PostInc()
+			return uint32(p.Register), 0, i.AddressingMode == addressingmode.RegisterIndirectWithPostIncrement && p.Register < 8
+		case PreDecOperand:
+			p, _ := op.PreDec()
+			return uint32(p.Register), 0, i.AddressingMode == addressingmode.RegisterIndirectWithPreDecrement && p.Register < 8
+		case IndirectOperand:
+			n, _ := op.Indirect()
+			return uint32(n.Register), 0, i.AddressingMode == addressingmode.RegisterIndirect && n.Register < 8
+		}
+	case displacement:
+		o, ok := op.Displacement()
+		if !ok || o.Width != s.width || o.Register >= 8 {
+			return 0, 0, false
+		}
+		if s.width == 16 {
+			return uint32(o.Register), uint32(uint16(o.Displacement)), o.Displacement == int32(int16(o.Displacement))
+		}
+		return uint32(o.Register), uint32(o.Displacement), true
+	case pcRelative:
+		p, ok := op.PCRelative()
+		if !ok || p.Width != s.width {
+			return 0, 0, false
+		}
+		if s.width == 8 {
+			return uint32(uint8(p.Offset)), 0, p.Offset == int32(int8(p.Offset))
+		}
+		return uint32(uint16(p.Offset)), 0, p.Offset == int32(int16(p.Offset))
+	case memoryIndirect:
+		m, ok := op.MemoryIndirect()
+		return uint32(m.Address), 0, ok
+	case control:
+		c, ok := op.ControlRegister()
+		return uint32(c), 0, ok && c <= EXR
+	case registerList:
+		l, ok := op.RegisterList()
+		if !ok || !l.valid() {
+			return 0, 0, false
+		}
+		if s.role == regDst {
+			return uint32(l.First + l.Count - 1), uint32(l.Count - 1), true
+		}
+		return uint32(l.First), uint32(l.Count - 1), true
+	case stackPointer:
+		switch op.Kind() {
+		case PostIncOperand:
+			p, _ := op.PostInc()
+			return 0, 0, i.AddressingMode == addressingmode.RegisterIndirectWithPostIncrement && p.Register == 7
+		case PreDecOperand:
+			p, _ := op.PreDec()
+			return 0, 0, i.AddressingMode == addressingmode.RegisterIndirectWithPreDecrement && p.Register == 7
+		}
+	}
+	return 0, 0, false
+}
+
+// registerFits returns whether r is a register which exists.
+func registerFits(r Register) bool {
+	if r.Size == size.Longword {
+		return r.Number < 8
+	}
+	return r.Number < 16
+}
@@ -0,0 +1,372 @@
+package instruction
+
+import (
+	"fmt"
+
+	"github.com/kn100/cybemu/size"
+)
+
+// MaxOperands is the most operands an instruction has.
+const MaxOperands = 2
+
+// OperandKind is the kind of an Operand.
+type OperandKind uint8
+
+// The kinds of Operand, one for each of the types it can hold. The zero
+// Operand is a NoOperand.
+const (
+	NoOperand OperandKind = iota
+	RegisterOperand
+	ImmediateOperand
+	AbsoluteOperand
+	IndirectOperand
+	DisplacementOperand
+	PostIncOperand
+	PreDecOperand
+	PCRelativeOperand
+	MemoryIndirectOperand
+	ControlRegisterOperand
+	RegisterListOperand
+)
+
+// Operand is one of the operands of an instruction, as returned by
+// Inst.Operands. Operands are held in the instruction itself, so that
+// decoding doesn't allocate, which is why this is a single struct tagged with
+// its kind rather than an interface. What it holds is got with the method
+// named after the kind, such as Register, which returns false for operands of
+// other kinds, and each of those types has an Operand method to go back.
+type Operand struct {
+	kind OperandKind
+	// reg is the register number of a Register, the register of an
+	// Indirect, Displacement, PostInc or PreDec, or the first register of a
+	// RegisterList.
+	reg uint8
+	// count is the number of registers in a RegisterList.
+	count uint8
+	width uint8
+	size  size.Size
+	// value is the value of an Immediate, the address of an Absolute or
+	// MemoryIndirect, a ControlRegister, or the displacement or offset of a
+	// Displacement or PCRelative.
+	value  uint32
+	target int
+}
+
+// Kind returns the kind of o.
+func (o Operand) Kind() OperandKind { return o.kind }
+
+// Register is a general register, at the size the instruction uses it.
+type Register struct {
+	// Number is the number of the register. For bytes, 0-7 are r0h-r7h and
+	// 8-15 are r0l-r7l. For words, 0-7 are r0-r7 and 8-15 are e0-e7. For
+	// longwords, 0-7 are er0-er7.
+	Number uint8
+	Size   size.Size
+}
+
+// Immediate is a value held in the instruction itself.
+type Immediate struct {
+	Value uint32
+	// Size is the size of the value, or size.Unset for small values such as
+	// bit numbers and the 1, 2 or 4 of adds, which are written in decimal.
+	Size size.Size
+}
+
+// Absolute is the memory at an address held in the instruction, written
+// @aa:8, @aa:16, @aa:24 or @aa:32.
+type Absolute struct {
+	// Address is the address as it is encoded. EffectiveAddress gives the
+	// address on the bus.
+	Address uint32
+	Width   int // Bits
+}
+
+// Indirect is the memory at the address in a register, written @ern.
+type Indirect struct {
+	Register uint8 // 0-7, for er0-er7
+}
+
+// Displacement is the memory at an offset from the address in a register,
+// written @(d:16, ern) or @(d:32, ern).
+type Displacement struct {
+	Register     uint8 // 0-7, for er0-er7
+	Displacement int32
+	Width        int // Bits
+}
+
+// PostInc is the memory at the address in a register, which is incremented
+// by the size of the access afterwards. It is written @ern+.
+type PostInc struct {
+	Register uint8 // 0-7, for er0-er7
+}
+
+// PreDec is the memory at the address in a register, which is first
+// decremented by the size of the access. It is written @-ern.
+type PreDec struct {
+	Register uint8 // 0-7, for er0-er7
+}
+
+// PCRelative is a branch target, given as an offset from the end of the
+// instruction.
+type PCRelative struct {
+	Offset int32
+	Width  int // Bits
+	// Target is the address branched to, worked out from the Pos of the
+	// instruction.
+	Target int
+}
+
+// MemoryIndirect is the memory at the address held in the longword at an 8
+// bit address, written @@aa:8. It is only used by jmp and jsr, for which it
+// is the address jumped to.
+type MemoryIndirect struct {
+	Address uint8
+}
+
+// ControlRegister is ccr or exr.
+type ControlRegister uint8
+
+const (
+	CCR ControlRegister = iota
+	EXR
+)
+
+// RegisterList is the run of longword registers loaded by ldm or stored by
+// stm, written (ern-erm). The manual only allows 2, 3 or 4 registers starting
+// with er0 or er4, or for 2, any even register.
+type RegisterList struct {
+	First uint8
+	Count uint8
+}
+
+// Operand returns r as an Operand.
+func (r Register) Operand() Operand {
+	return Operand{kind: RegisterOperand, reg: r.Number, size: r.Size}
+}
+
+// Operand returns i as an Operand.
+func (i Immediate) Operand() Operand {
+	return Operand{kind: ImmediateOperand, value: i.Value, size: i.Size}
+}
+
+// Operand returns a as an Operand.
+func (a Absolute) Operand() Operand {
+	return Operand{kind: AbsoluteOperand, value: a.Address, width: uint8(a.Width)}
+}
+
+// Operand returns i as an Operand.
+func (i Indirect) Operand() Operand {
+	return Operand{kind: IndirectOperand, reg: i.Register}
+}
+
+// Operand returns d as an Operand.
+func (d Displacement) Operand() Operand {
+	return Operand{kind: DisplacementOperand, reg: d.Register, value: uint32(d.Displacement), width: uint8(d.Width)}
+}
+
+// Operand returns p as an Operand.
+func (p PostInc) Operand() Operand {
+	return Operand{kind: PostIncOperand, reg: p.Register}
+}
+
+// Operand returns p as an Operand.
+func (p PreDec) Operand() Operand {
+	return Operand{kind: PreDecOperand, reg: p.Register}
+}
+
+// Operand returns p as an Operand.
+func (p PCRelative) Operand() Operand {
+	return Operand{kind: PCRelativeOperand, value: uint32(p.Offset), width: uint8(p.Width), target: p.Target}
+}
+
+// Operand returns m as an Operand.
+func (m MemoryIndirect) Operand() Operand {
+	return Operand{kind: MemoryIndirectOperand, value: uint32(m.Address)}
+}
+
+// Operand returns c as an Operand.
+func (c ControlRegister) Operand() Operand {
+	return Operand{kind: ControlRegisterOperand, value: uint32(c)}
+}
+
+// Operand returns r as an Operand.
+func (r RegisterList) Operand() Operand {
+	return Operand{kind: RegisterListOperand, reg: r.First, count: r.Count}
+}
+
+// Register returns o as a Register, if it is one.
+func (o Operand) Register() (Register, bool) {
+	return Register{Number: o.reg, Size: o.size}, o.kind == RegisterOperand
+}
+
+// Immediate returns o as an Immediate, if it is one.
+func (o Operand) Immediate() (Immediate, bool) {
+	return Immediate{Value: o.value, Size: o.size}, o.kind == ImmediateOperand
+}
+
+// Absolute returns o as an Absolute, if it is one.
+func (o Operand) Absolute() (Absolute, bool) {
+	return Absolute{Address: o.value, Width: int(o.width)}, o.kind == AbsoluteOperand
+}
+
+// Indirect returns o as an Indirect, if it is one.
+func (o Operand) Indirect() (Indirect, bool) {
+	return Indirect{Register: o.reg}, o.kind == IndirectOperand
+}
+
+// Displacement returns o as a Displacement, if it is one.
+func (o Operand) Displacement() (Displacement, bool) {
+	return Displacement{Register: o.reg, Displacement: int32(o.value), Width: int(o.width)}, o.kind == DisplacementOperand
+}
+
+// PostInc returns o as a PostInc, if it is one.
+func (o Operand) PostInc() (PostInc, bool) {
+	return PostInc{Register: o.reg}, o.kind == PostIncOperand
+}
+
+// PreDec returns o as a PreDec, if it is one.
+func (o Operand) PreDec() (PreDec, bool) {
+	return PreDec{Register: o.reg}, o.kind == PreDecOperand
+}
+
+// PCRelative returns o as a PCRelative, if it is one.
+func (o Operand) PCRelative() (PCRelative, bool) {
+	return PCRelative{Offset: int32(o.value), Width: int(o.width), Target: o.target}, o.kind == PCRelativeOperand
+}
+
+// MemoryIndirect returns o as a MemoryIndirect, if it is one.
+func (o Operand) MemoryIndirect() (MemoryIndirect, bool) {
+	return MemoryIndirect{Address: uint8(o.value)}, o.kind == MemoryIndirectOperand
+}
+
+// ControlRegister returns o as a ControlRegister, if it is one.
+func (o Operand) ControlRegister() (ControlRegister, bool) {
+	return ControlRegister(o.value), o.kind == ControlRegisterOperand
+}
+
+// RegisterList returns o as a RegisterList, if it is one.
+func (o Operand) RegisterList() (RegisterList, bool) {
+	return RegisterList{First: o.reg, Count: o.count}, o.kind == RegisterListOperand
+}
+
+// String returns the operand as it is written in assembly.
+func (o Operand) String() string {
+	switch o.kind {
+	case RegisterOperand:
+		r, _ := o.Register()
+		return r.String()
+	case ImmediateOperand:
+		i, _ := o.Immediate()
+		return i.String()
+	case AbsoluteOperand:
+		a, _ := o.Absolute()
+		return a.String()
+	case IndirectOperand:
+		i, _ := o.Indirect()
+		return i.String()
+	case DisplacementOperand:
+		d, _ := o.Displacement()
+		return d.String()
+	case PostIncOperand:
+		p, _ := o.PostInc()
+		return p.String()
+	case PreDecOperand:
+		p, _ := o.PreDec()
+		return p.String()
+	case PCRelativeOperand:
+		p, _ := o.PCRelative()
+		return p.String()
+	case MemoryIndirectOperand:
+		m, _ := o.MemoryIndirect()
+		return m.String()
+	case ControlRegisterOperand:
+		c, _ := o.ControlRegister()
+		return c.String()
+	case RegisterListOperand:
+		l, _ := o.RegisterList()
+		return l.String()
+	}
+	return "?"
+}
+
+func (r Register) String() string {
+	return toRegister(r.Number, r.Size)
+}
+
+func (i Immediate) String() string {
+	switch i.Size {
+	case size.Byte:
+		return fmt.Sprintf("#0x%02X", i.Value)
+	case size.Word:
+		return fmt.Sprintf("#0x%04X", i.Value)
+	case size.Longword:
+		return fmt.Sprintf("#0x%08X", i.Value)
+	}
+	return fmt.Sprintf("#%d", i.Value)
+}
+
+func (a Absolute) String() string {
+	return fmt.Sprintf("@0x%0*X:%d", a.Width/4, a.Address, a.Width)
+}
+
+// EffectiveAddress returns the address on the bus which a refers to. 8 bit
+// addresses are in the last 256 bytes of memory, 16 bit addresses are sign
+// extended, and only the low 24 bits of longer ones are used.
+func (a Absolute) EffectiveAddress() uint32 {
+	switch a.Width {
+	case 8:
+		return 0xFFFF00 | a.Address&0xFF
+	case 16:
+		return uint32(int32(int16(a.Address))) & 0xFFFFFF
+	}
+	return a.Address & 0xFFFFFF
+}
+
+func (i Indirect) String() string {
+	return "@" + toRegister(i.Register, size.Longword)
+}
+
+func (d Displacement) String() string {
+	if d.Width == 16 {
+		return fmt.Sprintf("@(0x%04X:16, %s)", uint16(d.Displacement), toRegister(d.Register, size.Longword))
+	}
+	return fmt.Sprintf("@(0x%08X:32, %s)", uint32(d.Displacement), toRegister(d.Register, size.Longword))
+}
+
+func (p PostInc) String() string {
+	return fmt.Sprintf("@%s+", toRegister(p.Register, size.Longword))
+}
+
+func (p PreDec) String() string {
+	return fmt.Sprintf("@-%s", toRegister(p.Register, size.Longword))
+}
+
+func (p PCRelative) String() string {
+	return fmt.Sprintf("0x%08X:%d", p.Target, p.Width)
+}
+
+func (m MemoryIndirect) String() string {
+	return fmt.Sprintf("@@0x%02X:8", m.Address)
+}
+
+func (c ControlRegister) String() string {
+	if c == EXR {
+		return "exr"
+	}
+	return "ccr"
+}
+
+func (r RegisterList) String() string {
+	return fmt.Sprintf("(er%d-er%d)", r.First, int(r.First)+int(r.Count)-1)
+}
+
+// valid reports whether the manual allows r.
+func (r RegisterList) valid() bool {
+	switch r.Count {
+	case 2:
+		return r.First%2 == 0 && r.First < 8
+	case 3, 4:
+		return r.First == 0 || r.First == 4
+	}
+	return false
+}
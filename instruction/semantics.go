@@ -0,0 +1,354 @@
+package instruction
+
+import (
+	"strings"
+
+	"github.com/kn100/cybemu/opcode"
+	"github.com/kn100/cybemu/size"
+)
+
+// Class says what an instruction does to the flow of control. An instruction
+// can be in more than one class, such as rte, which is a Return and
+// Privileged.
+type Class uint8
+
+const (
+	// Branch instructions jump to their operand: bra, the conditional
+	// branches and jmp. brn never branches, so it isn't one.
+	Branch Class = 1 << iota
+	// Conditional branches may instead carry on to the next instruction.
+	Conditional
+	// Call instructions are bsr and jsr.
+	Call
+	// Return instructions are rts and rte.
+	Return
+	// Trap instructions are trapa.
+	Trap
+	// Privileged instructions change the interrupt mask or stop the CPU. The
+	// H8S has no user mode, so anything can run them, but usually only
+	// system code does.
+	Privileged
+)
+
+// Flags is a set of CCR bits, held at their place in the register.
+type Flags uint8
+
+const (
+	FlagC Flags = 1 << iota
+	FlagV
+	FlagZ
+	FlagN
+	FlagU
+	FlagH
+	FlagUI
+	FlagI
+
+	AllFlags Flags = 0xFF
+)
+
+// String returns the names of the flags in f, from the top bit down, such
+// as "NZVC".
+func (f Flags) String() string {
+	names := [8]string{"C", "V", "Z", "N", "U", "H", "UI", "I"}
+	var b strings.Builder
+	for n := 7; n >= 0; n-- {
+		if f&(1<<n) != 0 {
+			b.WriteString(names[n])
+		}
+	}
+	return b.String()
+}
+
+// RegisterSet is a set of the registers er0-er7, with bit n set for ern.
+type RegisterSet uint8
+
+// Has reports whether ern is in r.
+func (r RegisterSet) Has(n uint8) bool {
+	return n < 8 && r&(1<<n) != 0
+}
+
+const sp RegisterSet = 1 << 7
+
+// access is what an instruction does with its last operand. Any before it
+// are only read.
+type access uint8
+
+const (
+	readOnly access = iota
+	writeOnly
+	readWrite
+)
+
+// semantics is what an instruction does, apart from what its operands say.
+type semantics struct {
+	class Class
+	dst   access
+	// The CCR bits read and written. ldc, stc and the like also read or
+	// write all of ccr through their operands.
+	flagsRead    Flags
+	flagsWritten Flags
+	// Registers used without being operands.
+	implicit RegisterSet
+	// The size of the memory operands, where it isn't the size of the
+	// instruction.
+	access size.Size
+}
+
+const (
+	hnzvc = FlagH | FlagN | FlagZ | FlagV | FlagC
+	nzv   = FlagN | FlagZ | FlagV
+	nzvc  = FlagN | FlagZ | FlagV | FlagC
+)
+
+// semanticsOf gives the semantics of each opcode. It's an array rather than a
+// map as Class is called for every instruction stepped by the profiler and
+// coverage.
+var semanticsOf = [opcode.Count]semantics{
+	opcode.Add:    {dst: readWrite, flagsWritten: hnzvc},
+	opcode.Adds:   {dst: readWrite},
+	opcode.Addx:   {dst: readWrite, flagsRead: FlagZ | FlagC, flagsWritten: hnzvc},
+	opcode.And:    {dst: readWrite, flagsWritten: nzv},
+	opcode.Andc:   {class: Privileged, dst: readWrite},
+	opcode.Band:   {flagsRead: FlagC, flagsWritten: FlagC, access: size.Byte},
+	opcode.Bcc:    {class: Branch | Conditional, flagsRead: FlagC},
+	opcode.Bclr:   {dst: readWrite, access: size.Byte},
+	opcode.Bcs:    {class: Branch | Conditional, flagsRead: FlagC},
+	opcode.Beq:    {class: Branch | Conditional, flagsRead: FlagZ},
+	opcode.Bge:    {class: Branch | Conditional, flagsRead: FlagN | FlagV},
+	opcode.Bgt:    {class: Branch | Conditional, flagsRead: FlagZ | FlagN | FlagV},
+	opcode.Bhi:    {class: Branch | Conditional, flagsRead: FlagC | FlagZ},
+	opcode.Biand:  {flagsRead: FlagC, flagsWritten: FlagC, access: size.Byte},
+	opcode.Bild:   {flagsWritten: FlagC, access: size.Byte},
+	opcode.Bior:   {flagsRead: FlagC, flagsWritten: FlagC, access: size.Byte},
+	opcode.Bist:   {dst: readWrite, flagsRead: FlagC, access: size.Byte},
+	opcode.Bixor:  {flagsRead: FlagC, flagsWritten: FlagC, access: size.Byte},
+	opcode.Bld:    {flagsWritten: FlagC, access: size.Byte},
+	opcode.Ble:    {class: Branch | Conditional, flagsRead: FlagZ | FlagN | FlagV},
+	opcode.Bls:    {class: Branch | Conditional, flagsRead: FlagC | FlagZ},
+	opcode.Blt:    {class: Branch | Conditional, flagsRead: FlagN | FlagV},
+	opcode.Bmi:    {class: Branch | Conditional, flagsRead: FlagN},
+	opcode.Bne:    {class: Branch | Conditional, flagsRead: FlagZ},
+	opcode.Bnot:   {dst: readWrite, access: size.Byte},
+	opcode.Bor:    {flagsRead: FlagC, flagsWritten: FlagC, access: size.Byte},
+	opcode.Bpl:    {class: Branch | Conditional, flagsRead: FlagN},
+	opcode.Bra:    {class: Branch},
+	opcode.Brn:    {},
+	opcode.Bset:   {dst: readWrite, access: size.Byte},
+	opcode.Bsr:    {class: Call, implicit: sp},
+	opcode.Bst:    {dst: readWrite, flagsRead: FlagC, access: size.Byte},
+	opcode.Btst:   {flagsWritten: FlagZ, access: size.Byte},
+	opcode.Bvc:    {class: Branch | Conditional, flagsRead: FlagV},
+	opcode.Bvs:    {class: Branch | Conditional, flagsRead: FlagV},
+	opcode.Bxor:   {flagsRead: FlagC, flagsWritten: FlagC, access: size.Byte},
+	opcode.Clrmac: {},
+	opcode.Cmp:    {flagsWritten: hnzvc},
+	opcode.Daa:    {dst: readWrite, flagsRead: FlagH | FlagC, flagsWritten: hnzvc},
+	opcode.Das:    {dst: readWrite, flagsRead: FlagH | FlagC, flagsWritten: hnzvc},
+	opcode.Dec:    {dst: readWrite, flagsWritten: nzv},
+	opcode.Divxs:  {dst: readWrite, flagsWritten: FlagN | FlagZ},
+	opcode.Divxu:  {dst: readWrite, flagsWritten: FlagN | FlagZ},
+	opcode.Eepmov: {access: size.Byte},
+	opcode.Exts:   {dst: readWrite, flagsWritten: nzv},
+	opcode.Extu:   {dst: readWrite, flagsWritten: nzv},
+	opcode.Inc:    {dst: readWrite, flagsWritten: nzv},
+	opcode.Jmp:    {class: Branch, access: size.Longword},
+	opcode.Jsr:    {class: Call, implicit: sp, access: size.Longword},
+	opcode.Ldc:    {class: Privileged, dst: writeOnly, access: size.Word},
+	opcode.Ldm:    {implicit: sp, access: size.Longword},
+	opcode.Ldmac:  {},
+	opcode.Mac:    {},
+	opcode.Mov:    {dst: writeOnly, flagsWritten: nzv},
+	opcode.Movfpe: {dst: writeOnly, flagsWritten: nzv},
+	opcode.Movtpe: {dst: writeOnly, flagsWritten: nzv},
+	opcode.Mulxs:  {dst: readWrite, flagsWritten: FlagN | FlagZ},
+	opcode.Mulxu:  {dst: readWrite},
+	opcode.Neg:    {dst: readWrite, flagsWritten: hnzvc},
+	opcode.Nop:    {},
+	opcode.Not:    {dst: readWrite, flagsWritten: nzv},
+	opcode.Or:     {dst: readWrite, flagsWritten: nzv},
+	opcode.Orc:    {class: Privileged, dst: readWrite},
+	opcode.Pop:    {dst: writeOnly, flagsWritten: nzv, implicit: sp},
+	opcode.Push:   {flagsWritten: nzv, implicit: sp},
+	opcode.Rotl:   {dst: readWrite, flagsWritten: nzvc},
+	opcode.Rotr:   {dst: readWrite, flagsWritten: nzvc},
+	opcode.Rotxl:  {dst: readWrite, flagsRead: FlagC, flagsWritten: nzvc},
+	opcode.Rotxr:  {dst: readWrite, flagsRead: FlagC, flagsWritten: nzvc},
+	opcode.Rte:    {class: Return | Privileged, flagsWritten: AllFlags, implicit: sp},
+	opcode.Rts:    {class: Return, implicit: sp},
+	opcode.Shal:   {dst: readWrite, flagsWritten: nzvc},
+	opcode.Shar:   {dst: readWrite, flagsWritten: nzvc},
+	opcode.Shll:   {dst: readWrite, flagsWritten: nzvc},
+	opcode.Shlr:   {dst: readWrite, flagsWritten: nzvc},
+	opcode.Sleep:  {class: Privileged},
+	opcode.Stc:    {dst: writeOnly, access: size.Word},
+	opcode.Stm:    {implicit: sp, access: size.Longword},
+	opcode.Stmac:  {dst: writeOnly, flagsWritten: nzv},
+	opcode.Sub:    {dst: readWrite, flagsWritten: hnzvc},
+	opcode.Subs:   {dst: readWrite},
+	opcode.Subx:   {dst: readWrite, flagsRead: FlagZ | FlagC, flagsWritten: hnzvc},
+	opcode.Tas:    {dst: readWrite, flagsWritten: nzv, access: size.Byte},
+	opcode.Trapa:  {class: Trap, flagsWritten: FlagI | FlagUI, implicit: sp},
+	opcode.Xor:    {dst: readWrite, flagsWritten: nzv},
+	opcode.Xorc:   {class: Privileged, dst: readWrite},
+}
+
+// Class returns the classes i is in, or 0 if it carries on to the next
+// instruction like most do. It only needs the Opcode.
+func (i *Inst) Class() Class {
+	return semanticsOf[i.Opcode].class
+}
+
+// FallsThrough reports whether the instruction after i can run next, which
+// it can unless i always branches or returns. Calls and traps come back to
+// it, as does sleep once the interrupt which wakes it returns. Invalid
+// instructions don't run at all. It only needs the Opcode.
+func (i *Inst) FallsThrough() bool {
+	c := i.Class()
+	switch {
+	case i.Opcode == opcode.Invalid:
+		return false
+	case c&(Conditional|Call|Trap) != 0:
+		return true
+	case c&(Branch|Return) != 0:
+		return false
+	}
+	return true
+}
+
+// Reads returns the registers i reads. A register is read if any part of it
+// is, so mov.b r0l, r1l reads er0. The operands must have been set by
+// DetermineOperandTypeAndSetData.
+func (i *Inst) Reads() RegisterSet {
+	r, _ := i.registers()
+	return r
+}
+
+// Writes returns the registers i writes. A register is written if any part of
+// it is, so mov.b r0l, r1l writes er1. The operands must have been set by
+// DetermineOperandTypeAndSetData.
+func (i *Inst) Writes() RegisterSet {
+	_, w := i.registers()
+	return w
+}
+
+// Accesses reports whether i reads and writes what its nth operand refers
+// to: the register itself, or for memory operands, the memory. All but the
+// last operand are only read. Branch targets count as read.
+func (i *Inst) Accesses(n int) (read, written bool) {
+	a := readOnly
+	if n == len(i.Operands())-1 {
+		a = semanticsOf[i.Opcode].dst
+	}
+	return a != writeOnly, a != readOnly
+}
+
+func (i *Inst) registers() (read, written RegisterSet) {
+	s := semanticsOf[i.Opcode]
+	read, written = s.implicit, s.implicit
+	for n, op := range i.Operands() {
+		switch op.Kind() {
+		case RegisterOperand:
+			reg, _ := op.Register()
+			r := RegisterSet(1) << (reg.Number & 7)
+			reads, writes := i.Accesses(n)
+			if reads {
+				read |= r
+			}
+			if writes {
+				written |= r
+			}
+		case IndirectOperand, DisplacementOperand:
+			read |= 1 << op.reg
+		case PostIncOperand, PreDecOperand:
+			read |= 1 << op.reg
+			written |= 1 << op.reg
+		}
+	}
+
+	switch i.Opcode {
+	case opcode.Eepmov:
+		// er5 and er6 hold the source and destination, and r4l or r4 the
+		// number of bytes.
+		read |= 0x70
+		written |= 0x70
+	case opcode.Ldm, opcode.Stm:
+		for _, op := range i.Operands() {
+			if l, ok := op.RegisterList(); ok {
+				list := RegisterSet(1<<l.Count-1) << l.First
+				if i.Opcode == opcode.Ldm {
+					written |= list
+				} else {
+					read |= list
+				}
+			}
+		}
+	}
+	return read, written
+}
+
+// FlagsRead returns the CCR bits i reads.
+func (i *Inst) FlagsRead() Flags {
+	r, _ := i.flags()
+	return r
+}
+
+// FlagsWritten returns the CCR bits i changes, including any it leaves
+// undefined.
+func (i *Inst) FlagsWritten() Flags {
+	_, w := i.flags()
+	return w
+}
+
+func (i *Inst) flags() (read, written Flags) {
+	s := semanticsOf[i.Opcode]
+	read, written = s.flagsRead, s.flagsWritten
+	for n, op := range i.Operands() {
+		if c, ok := op.ControlRegister(); !ok || c != CCR {
+			continue
+		}
+		reads, writes := i.Accesses(n)
+		if reads {
+			read = AllFlags
+		}
+		if writes {
+			written = AllFlags
+		}
+	}
+	return read, written
+}
+
+// AccessSize returns the size of the memory i reads or writes through its
+// operands, or size.Unset if it doesn't. push, pop, ldm, stm and eepmov
+// count, but the return addresses and ccr saved and restored by calls,
+// returns and traps don't. jmp and jsr only access memory for @@aa:8, where
+// they read the address to jump to.
+func (i *Inst) AccessSize() size.Size {
+	s := semanticsOf[i.Opcode]
+	switch i.Opcode {
+	case opcode.Push, opcode.Pop, opcode.Ldm, opcode.Stm, opcode.Eepmov:
+	case opcode.Jmp, opcode.Jsr:
+		ops := i.Operands()
+		if len(ops) != 1 || ops[0].Kind() != MemoryIndirectOperand {
+			return size.Unset
+		}
+	default:
+		if !i.accessesMemory() {
+			return size.Unset
+		}
+	}
+	if s.access != size.Unset {
+		return s.access
+	}
+	return i.BWL
+}
+
+// accessesMemory reports whether any of the operands of i are in memory.
+func (i *Inst) accessesMemory() bool {
+	for _, op := range i.Operands() {
+		switch op.Kind() {
+		case AbsoluteOperand, IndirectOperand, DisplacementOperand, PostIncOperand, PreDecOperand, MemoryIndirectOperand:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package instruction_test
+
+import (
+	"testing"
+
+	"github.com/kn100/cybemu/instruction"
+	"github.com/kn100/cybemu/size"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemantics(t *testing.T) {
+	testCases := []struct {
+		input        []byte
+		expected     string
+		class        instruction.Class
+		reads        instruction.RegisterSet
+		writes       instruction.RegisterSet
+		flagsRead    instruction.Flags
+		flagsWritten instruction.Flags
+		access       size.Size
+	}{
+		{input: []byte{0x00, 0x00}, expected: "nop"},
+		{input: []byte{0x08, 0x3E}, expected: "add.b r3h, r6l", reads: 0x48, writes: 0x40, flagsWritten: 0x2F},
+		{input: []byte{0x0F, 0x92}, expected: "mov.l er1, er2", reads: 0x02, writes: 0x04, flagsWritten: 0x0E},
+		{input: []byte{0x1D, 0x12}, expected: "cmp.w r1, r2", reads: 0x06, flagsWritten: 0x2F},
+		{input: []byte{0x0E, 0x12}, expected: "addx r1h, r2h", reads: 0x06, writes: 0x04, flagsRead: 0x05, flagsWritten: 0x2F},
+		{input: []byte{0x6E, 0x1A, 0xFF, 0xFC}, expected: "mov.b @(0xFFFC:16, er1), r2l", reads: 0x02, writes: 0x04, flagsWritten: 0x0E, access: size.Byte},
+		{input: []byte{0x01, 0x00, 0x6D, 0x45}, expected: "mov.l @er4+, er5", reads: 0x10, writes: 0x30, flagsWritten: 0x0E, access: size.Longword},
+		{input: []byte{0x6D, 0xF2}, expected: "push.w r2", reads: 0x84, writes: 0x80, flagsWritten: 0x0E, access: size.Word},
+		{input: []byte{0x7D, 0x40, 0x72, 0x30}, expected: "bclr #3, @er4", reads: 0x10, access: size.Byte},
+		{input: []byte{0x77, 0x3A}, expected: "bld #3, r2l", reads: 0x04, flagsWritten: 0x01},
+		{input: []byte{0x45, 0x10}, expected: "bcs 0x00000012:8", class: instruction.Branch | instruction.Conditional, flagsRead: 0x01},
+		{input: []byte{0x40, 0x10}, expected: "bra 0x00000012:8", class: instruction.Branch},
+		{input: []byte{0x41, 0x10}, expected: "brn 0x00000012:8"},
+		{input: []byte{0x59, 0x30}, expected: "jmp @er3", class: instruction.Branch, reads: 0x08},
+		{input: []byte{0x5F, 0x20}, expected: "jsr @@0x20:8", class: instruction.Call, reads: 0x80, writes: 0x80, access: size.Longword},
+		{input: []byte{0x54, 0x70}, expected: "rts", class: instruction.Return, reads: 0x80, writes: 0x80},
+		{input: []byte{0x57, 0x20}, expected: "trapa #2", class: instruction.Trap, reads: 0x80, writes: 0x80, flagsWritten: 0xC0},
+		{input: []byte{0x06, 0x7F}, expected: "andc #0x7F, ccr", class: instruction.Privileged, flagsRead: 0xFF, flagsWritten: 0xFF},
+		{input: []byte{0x02, 0x0B}, expected: "stc.b ccr, r3l", writes: 0x08, flagsRead: 0xFF},
+		{input: []byte{0x01, 0x10, 0x6D, 0x71}, expected: "ldm.l @sp+, (er0-er1)", reads: 0x80, writes: 0x83, access: size.Longword},
+		{input: []byte{0x01, 0x20, 0x6D, 0xF4}, expected: "stm.l (er4-er6), @-sp", reads: 0xF0, writes: 0x80, access: size.Longword},
+		{input: []byte{0x7B, 0x5C, 0x59, 0x8F}, expected: "eepmov.b", reads: 0x70, writes: 0x70, access: size.Byte},
+	}
+	for _, tc := range testCases {
+		inst := decode(tc.input)
+		assert.Equal(t, tc.expected, inst.String())
+		assert.Equal(t, tc.class, inst.Class(), tc.expected)
+		assert.Equal(t, tc.reads, inst.Reads(), tc.expected)
+		assert.Equal(t, tc.writes, inst.Writes(), tc.expected)
+		assert.Equal(t, tc.flagsRead, inst.FlagsRead(), tc.expected)
+		assert.Equal(t, tc.flagsWritten, inst.FlagsWritten(), tc.expected)
+		assert.Equal(t, tc.access, inst.AccessSize(), tc.expected)
+	}
+}
+
+func TestFlagsString(t *testing.T) {
+	assert.Equal(t, "NZVC", (instruction.FlagN | instruction.FlagZ | instruction.FlagV | instruction.FlagC).String())
+	assert.Equal(t, "IUIHUNZVC", instruction.AllFlags.String())
+	assert.Equal(t, "", instruction.Flags(0).String())
+}
+
+func TestFallsThrough(t *testing.T) {
+	testCases := []struct {
+		input    []byte
+		expected bool
+	}{
+		{input: []byte{0x00, 0x00}, expected: true},  // nop
+		{input: []byte{0x45, 0x10}, expected: true},  // bcs
+		{input: []byte{0x5F, 0x20}, expected: true},  // jsr
+		{input: []byte{0x57, 0x20}, expected: true},  // trapa
+		{input: []byte{0x01, 0x80}, expected: true},  // sleep
+		{input: []byte{0x40, 0x10}, expected: false}, // bra
+		{input: []byte{0x59, 0x30}, expected: false}, // jmp
+		{input: []byte{0x54, 0x70}, expected: false}, // rts
+		{input: []byte{0x01, 0x01}, expected: false}, // invalid
+	}
+	for _, tc := range testCases {
+		inst := decode(tc.input)
+		assert.Equal(t, tc.expected, inst.FallsThrough(), "% X", tc.input)
+	}
+}
@@ -0,0 +1,3349 @@
+// Code generated by "gen -in instructions.txt"; DO NOT EDIT.
+
+package instruction
+
+import (
+	"github.com/kn100/cybemu/addressingmode"
+	"github.com/kn100/cybemu/opcode"
+	"github.com/kn100/cybemu/operand"
+	"github.com/kn100/cybemu/size"
+)
+
+var forms = [...]form{
+	0: { // 00 00
+		mask: 0xFFFF000000000000, value: 0x0000000000000000, length: 2, complete: true,
+		opcode: opcode.Nop, bwl: size.Unset, mode: addressingmode.None,
+		operandSize: 0, operandType: operand.None,
+	},
+	1: { // 02 [000c]d
+		mask: 0xFFE0000000000000, value: 0x0200000000000000, length: 2, complete: true,
+		opcode: opcode.Stc, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_STC,
+		fields: []field{{role: ctl, start: 11, width: 1}, {role: regDst, start: 12, width: 4}},
+	},
+	2: { // 03 [000c]s
+		mask: 0xFFE0000000000000, value: 0x0300000000000000, length: 2, complete: true,
+		opcode: opcode.Ldc, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_LDC,
+		fields: []field{{role: ctl, start: 11, width: 1}, {role: regSrc, start: 12, width: 4}},
+	},
+	3: { // 04 ii
+		mask: 0xFF00000000000000, value: 0x0400000000000000, length: 2, complete: true,
+		opcode: opcode.Orc, bwl: size.Unset, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_CCR,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	4: { // 05 ii
+		mask: 0xFF00000000000000, value: 0x0500000000000000, length: 2, complete: true,
+		opcode: opcode.Xorc, bwl: size.Unset, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_CCR,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	5: { // 06 ii
+		mask: 0xFF00000000000000, value: 0x0600000000000000, length: 2, complete: true,
+		opcode: opcode.Andc, bwl: size.Unset, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_CCR,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	6: { // 07 ii
+		mask: 0xFF00000000000000, value: 0x0700000000000000, length: 2, complete: true,
+		opcode: opcode.Ldc, bwl: size.Byte, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_CCR,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	7: { // 08 sd
+		mask: 0xFF00000000000000, value: 0x0800000000000000, length: 2, complete: true,
+		opcode: opcode.Add, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	8: { // 09 sd
+		mask: 0xFF00000000000000, value: 0x0900000000000000, length: 2, complete: true,
+		opcode: opcode.Add, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16_R16,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	9: { // 0C sd
+		mask: 0xFF00000000000000, value: 0x0C00000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	10: { // 0D sd
+		mask: 0xFF00000000000000, value: 0x0D00000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16_R16,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	11: { // 0E sd
+		mask: 0xFF00000000000000, value: 0x0E00000000000000, length: 2, complete: true,
+		opcode: opcode.Addx, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	12: { // 01 00 69 [0sss][0ddd]
+		mask: 0xFFFFFF8800000000, value: 0x0100690000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.AR32_R32,
+		fields: []field{{role: regSrc, start: 25, width: 3}, {role: regDst, start: 29, width: 3}},
+	},
+	13: { // 01 00 69 d{1...}[0sss]
+		mask: 0xFFFFFF8800000000, value: 0x0100698000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.R32_AR32,
+		fields: []field{{role: regDst, start: 24, width: 4}, {role: regSrc, start: 29, width: 3}},
+	},
+	14: { // 01 00 6F [0sss][0ddd] iiii
+		mask: 0xFFFFFF8800000000, value: 0x01006F0000000000, length: 6, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.AI16R32_R32,
+		fields: []field{{role: regSrc, start: 25, width: 3}, {role: regDst, start: 29, width: 3}, {role: imm, start: 32, width: 16}},
+	},
+	15: { // 01 00 6F d{1...}[0sss] iiii
+		mask: 0xFFFFFF8800000000, value: 0x01006F8000000000, length: 6, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.R32_AI16R32,
+		fields: []field{{role: regDst, start: 24, width: 4}, {role: regSrc, start: 29, width: 3}, {role: imm, start: 32, width: 16}},
+	},
+	16: { // 01 00 78 [0sss]0 6B 2[0ddd] iiii iiii
+		mask: 0xFFFFFF8FFFF80000, value: 0x010078006B200000, length: 10, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.AI32R32_R32,
+		fields: []field{{role: regSrc, start: 25, width: 3}, {role: regDst, start: 45, width: 3}, {role: imm, start: 48, width: 32}},
+	},
+	17: { // 01 00 78 [0ddd]0 6B A[0sss] iiii iiii
+		mask: 0xFFFFFF8FFFF80000, value: 0x010078006BA00000, length: 10, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.R32_AI32R32,
+		fields: []field{{role: regDst, start: 25, width: 3}, {role: regSrc, start: 45, width: 3}, {role: imm, start: 48, width: 32}},
+	},
+	18: { // 01 00 6B 0[0ddd] iiii
+		mask: 0xFFFFFFF800000000, value: 0x01006B0000000000, length: 6, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.AI16_R32,
+		fields: []field{{role: regDst, start: 29, width: 3}, {role: imm, start: 32, width: 16}},
+	},
+	19: { // 01 00 6B 8[0sss] iiii
+		mask: 0xFFFFFFF800000000, value: 0x01006B8000000000, length: 6, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R32_AI16,
+		fields: []field{{role: regSrc, start: 29, width: 3}, {role: imm, start: 32, width: 16}},
+	},
+	20: { // 01 00 6B 2[0ddd] iiii iiii
+		mask: 0xFFFFFFF800000000, value: 0x01006B2000000000, length: 8, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.AI32_R32,
+		fields: []field{{role: regDst, start: 29, width: 3}, {role: imm, start: 32, width: 32}},
+	},
+	21: { // 01 00 6B A[0sss] iiii iiii
+		mask: 0xFFFFFFF800000000, value: 0x01006BA000000000, length: 8, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R32_AI32,
+		fields: []field{{role: regSrc, start: 29, width: 3}, {role: imm, start: 32, width: 32}},
+	},
+	22: { // 01 00 6D 7[0ddd]
+		mask: 0xFFFFFFF800000000, value: 0x01006D7000000000, length: 4, complete: true,
+		opcode: opcode.Pop, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithPostIncrement,
+		operandSize: 0, operandType: operand.R32,
+		fields: []field{{role: regDst, start: 29, width: 3}},
+	},
+	23: { // 01 00 6D [0sss][0ddd]
+		mask: 0xFFFFFF8800000000, value: 0x01006D0000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithPostIncrement,
+		operandSize: 0, operandType: operand.AR32_R32,
+		fields: []field{{role: regSrc, start: 25, width: 3}, {role: regDst, start: 29, width: 3}},
+	},
+	24: { // 01 00 6D F[0ddd]
+		mask: 0xFFFFFFF800000000, value: 0x01006DF000000000, length: 4, complete: true,
+		opcode: opcode.Push, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithPreDecrement,
+		operandSize: 0, operandType: operand.R32,
+		fields: []field{{role: regDst, start: 29, width: 3}},
+	},
+	25: { // 01 10 6D 7[0ddd]
+		mask: 0xFFFFFFF800000000, value: 0x01106D7000000000, length: 4, complete: true,
+		opcode: opcode.Ldm, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithPostIncrement,
+		operandSize: 0, operandType: operand.AR32_R32R32,
+		fields: []field{{role: regDst, start: 29, width: 3}, {role: imm, value: 1}},
+	},
+	26: { // 01 20 6D 7[0ddd]
+		mask: 0xFFFFFFF800000000, value: 0x01206D7000000000, length: 4, complete: true,
+		opcode: opcode.Ldm, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithPostIncrement,
+		operandSize: 0, operandType: operand.AR32_R32R32,
+		fields: []field{{role: regDst, start: 29, width: 3}, {role: imm, value: 2}},
+	},
+	27: { // 01 30 6D 7[0ddd]
+		mask: 0xFFFFFFF800000000, value: 0x01306D7000000000, length: 4, complete: true,
+		opcode: opcode.Ldm, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithPostIncrement,
+		operandSize: 0, operandType: operand.AR32_R32R32,
+		fields: []field{{role: regDst, start: 29, width: 3}, {role: imm, value: 3}},
+	},
+	28: { // 01 10 6D F[0sss]
+		mask: 0xFFFFFFF800000000, value: 0x01106DF000000000, length: 4, complete: true,
+		opcode: opcode.Stm, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithPreDecrement,
+		operandSize: 0, operandType: operand.R32R32_AR32,
+		fields: []field{{role: regSrc, start: 29, width: 3}, {role: imm, value: 1}},
+	},
+	29: { // 01 20 6D F[0sss]
+		mask: 0xFFFFFFF800000000, value: 0x01206DF000000000, length: 4, complete: true,
+		opcode: opcode.Stm, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithPreDecrement,
+		operandSize: 0, operandType: operand.R32R32_AR32,
+		fields: []field{{role: regSrc, start: 29, width: 3}, {role: imm, value: 2}},
+	},
+	30: { // 01 30 6D F[0sss]
+		mask: 0xFFFFFFF800000000, value: 0x01306DF000000000, length: 4, complete: true,
+		opcode: opcode.Stm, bwl: size.Longword, mode: addressingmode.RegisterIndirectWithPreDecrement,
+		operandSize: 0, operandType: operand.R32R32_AR32,
+		fields: []field{{role: regSrc, start: 29, width: 3}, {role: imm, value: 3}},
+	},
+	31: { // 01 4[000c] 69 [0sss]-
+		mask: 0xFFFEFF8000000000, value: 0x0140690000000000, length: 4, complete: true,
+		opcode: opcode.Ldc, bwl: size.Word, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.R8_LDC,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: regSrc, start: 25, width: 3}},
+	},
+	32: { // 01 4[000c] 69 d{1...}-
+		mask: 0xFFFEFF8000000000, value: 0x0140698000000000, length: 4, complete: true,
+		opcode: opcode.Stc, bwl: size.Word, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.R8_STC,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: regDst, start: 24, width: 4}},
+	},
+	33: { // 01 4[000c] 6F [0sss]- iiii
+		mask: 0xFFFEFF8000000000, value: 0x01406F0000000000, length: 6, complete: true,
+		opcode: opcode.Ldc, bwl: size.Word, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.AI16R32_CCR,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: regSrc, start: 25, width: 3}, {role: imm, start: 32, width: 16}},
+	},
+	34: { // 01 4[000c] 6F d{1...}- iiii
+		mask: 0xFFFEFF8000000000, value: 0x01406F8000000000, length: 6, complete: true,
+		opcode: opcode.Stc, bwl: size.Word, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.CCR_AI16R32,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: regDst, start: 24, width: 4}, {role: imm, start: 32, width: 16}},
+	},
+	35: { // 01 4[000c] 78 [0sss]- 6B 20 iiii iiii
+		mask: 0xFFFEFF80FFFF0000, value: 0x014078006B200000, length: 10, complete: true,
+		opcode: opcode.Ldc, bwl: size.Word, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.AI32R32_CCR,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: regSrc, start: 25, width: 3}, {role: imm, start: 48, width: 32}},
+	},
+	36: { // 01 4[000c] 78 [0ddd]- 6B A0 iiii iiii
+		mask: 0xFFFEFF80FFFF0000, value: 0x014078006BA00000, length: 10, complete: true,
+		opcode: opcode.Stc, bwl: size.Word, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.CCR_AI32R32,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: regDst, start: 25, width: 3}, {role: imm, start: 48, width: 32}},
+	},
+	37: { // 01 4[000c] 6D d{1...}-
+		mask: 0xFFFEFF8000000000, value: 0x01406D8000000000, length: 4, complete: true,
+		opcode: opcode.Stc, bwl: size.Word, mode: addressingmode.RegisterIndirectWithPreDecrement,
+		operandSize: 0, operandType: operand.R8_STC,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: regDst, start: 24, width: 4}},
+	},
+	38: { // 01 4[000c] 6D [0sss]-
+		mask: 0xFFFEFF8000000000, value: 0x01406D0000000000, length: 4, complete: true,
+		opcode: opcode.Ldc, bwl: size.Word, mode: addressingmode.RegisterIndirectWithPostIncrement,
+		operandSize: 0, operandType: operand.R8_LDC,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: regSrc, start: 25, width: 3}},
+	},
+	39: { // 01 4[000c] 6B 00 iiii
+		mask: 0xFFFEFFFF00000000, value: 0x01406B0000000000, length: 6, complete: true,
+		opcode: opcode.Ldc, bwl: size.Word, mode: addressingmode.AbsoluteAddress,
+		operandSize: 16, operandType: operand.AI16_CCR,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: imm, start: 32, width: 16}},
+	},
+	40: { // 01 4[000c] 6B 20 iiii iiii
+		mask: 0xFFFEFFFF00000000, value: 0x01406B2000000000, length: 8, complete: true,
+		opcode: opcode.Ldc, bwl: size.Word, mode: addressingmode.AbsoluteAddress,
+		operandSize: 32, operandType: operand.AI32_CCR,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: imm, start: 32, width: 32}},
+	},
+	41: { // 01 4[000c] 6B 80 iiii
+		mask: 0xFFFEFFFF00000000, value: 0x01406B8000000000, length: 6, complete: true,
+		opcode: opcode.Stc, bwl: size.Word, mode: addressingmode.AbsoluteAddress,
+		operandSize: 16, operandType: operand.CCR_AI16,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: imm, start: 32, width: 16}},
+	},
+	42: { // 01 4[000c] 6B A0 iiii iiii
+		mask: 0xFFFEFFFF00000000, value: 0x01406BA000000000, length: 8, complete: true,
+		opcode: opcode.Stc, bwl: size.Word, mode: addressingmode.AbsoluteAddress,
+		operandSize: 32, operandType: operand.CCR_AI32,
+		fields: []field{{role: ctl, start: 15, width: 1}, {role: imm, start: 32, width: 32}},
+	},
+	43: { // 01 41 04 ii
+		mask: 0xFFFFFF0000000000, value: 0x0141040000000000, length: 4, complete: true,
+		opcode: opcode.Orc, bwl: size.Unset, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_EXR,
+		fields: []field{{role: imm, start: 24, width: 8}, {role: ctl, value: 1}},
+	},
+	44: { // 01 41 05 ii
+		mask: 0xFFFFFF0000000000, value: 0x0141050000000000, length: 4, complete: true,
+		opcode: opcode.Xorc, bwl: size.Unset, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_EXR,
+		fields: []field{{role: imm, start: 24, width: 8}, {role: ctl, value: 1}},
+	},
+	45: { // 01 41 06 ii
+		mask: 0xFFFFFF0000000000, value: 0x0141060000000000, length: 4, complete: true,
+		opcode: opcode.Andc, bwl: size.Unset, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_EXR,
+		fields: []field{{role: imm, start: 24, width: 8}, {role: ctl, value: 1}},
+	},
+	46: { // 01 41 07 ii
+		mask: 0xFFFFFF0000000000, value: 0x0141070000000000, length: 4, complete: true,
+		opcode: opcode.Ldc, bwl: size.Byte, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_EXR,
+		fields: []field{{role: imm, start: 24, width: 8}, {role: ctl, value: 1}},
+	},
+	47: { // 01 80
+		mask: 0xFFFF000000000000, value: 0x0180000000000000, length: 2, complete: true,
+		opcode: opcode.Sleep, bwl: size.Unset, mode: addressingmode.None,
+		operandSize: 0, operandType: operand.None,
+	},
+	48: { // 01 E0 7B [0rrr]C
+		mask: 0xFFFFFF8F00000000, value: 0x01E07B0C00000000, length: 4, complete: true,
+		opcode: opcode.Tas, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.S4_R32,
+		fields: []field{{role: reg, start: 25, width: 3}},
+	},
+	49: { // 01 C0 50 sd
+		mask: 0xFFFFFF0000000000, value: 0x01C0500000000000, length: 4, complete: true,
+		opcode: opcode.Mulxs, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R16_MULXS_DIVXS,
+		fields: []field{{role: regSrc, start: 24, width: 4}, {role: regDst, start: 28, width: 4}},
+	},
+	50: { // 01 C0 52 s[0ddd]
+		mask: 0xFFFFFF0800000000, value: 0x01C0520000000000, length: 4, complete: true,
+		opcode: opcode.Mulxs, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16_R32_MULXS_DIVXS,
+		fields: []field{{role: regSrc, start: 24, width: 4}, {role: regDst, start: 29, width: 3}},
+	},
+	51: { // 01 D0 51 sd
+		mask: 0xFFFFFF0000000000, value: 0x01D0510000000000, length: 4, complete: true,
+		opcode: opcode.Divxs, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R16_MULXS_DIVXS,
+		fields: []field{{role: regSrc, start: 24, width: 4}, {role: regDst, start: 28, width: 4}},
+	},
+	52: { // 01 D0 53 s[0ddd]
+		mask: 0xFFFFFF0800000000, value: 0x01D0530000000000, length: 4, complete: true,
+		opcode: opcode.Divxs, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16_R32_MULXS_DIVXS,
+		fields: []field{{role: regSrc, start: 24, width: 4}, {role: regDst, start: 29, width: 3}},
+	},
+	53: { // 01 F0 64 [0sss][0ddd]
+		mask: 0xFFFFFF8800000000, value: 0x01F0640000000000, length: 4, complete: true,
+		opcode: opcode.Or, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R32_R32_S4,
+		fields: []field{{role: regSrc, start: 25, width: 3}, {role: regDst, start: 29, width: 3}},
+	},
+	54: { // 01 F0 65 [0sss][0ddd]
+		mask: 0xFFFFFF8800000000, value: 0x01F0650000000000, length: 4, complete: true,
+		opcode: opcode.Xor, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R32_R32_S4,
+		fields: []field{{role: regSrc, start: 25, width: 3}, {role: regDst, start: 29, width: 3}},
+	},
+	55: { // 01 F0 66 [0sss][0ddd]
+		mask: 0xFFFFFF8800000000, value: 0x01F0660000000000, length: 4, complete: true,
+		opcode: opcode.And, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R32_R32_S4,
+		fields: []field{{role: regSrc, start: 25, width: 3}, {role: regDst, start: 29, width: 3}},
+	},
+	56: { // 0A 0d
+		mask: 0xFFF0000000000000, value: 0x0A00000000000000, length: 2, complete: true,
+		opcode: opcode.Inc, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_INC_DEC,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	57: { // 0A s{1...}[0ddd]
+		mask: 0xFF88000000000000, value: 0x0A80000000000000, length: 2, complete: true,
+		opcode: opcode.Add, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R32_R32_S2,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 13, width: 3}},
+	},
+	58: { // 0B 0[0ddd]
+		mask: 0xFFF8000000000000, value: 0x0B00000000000000, length: 2, complete: true,
+		opcode: opcode.Adds, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_ADDS_SUBS,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	59: { // 0B 8[0ddd]
+		mask: 0xFFF8000000000000, value: 0x0B80000000000000, length: 2, complete: true,
+		opcode: opcode.Adds, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_ADDS_SUBS,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	60: { // 0B 9[0ddd]
+		mask: 0xFFF8000000000000, value: 0x0B90000000000000, length: 2, complete: true,
+		opcode: opcode.Adds, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_ADDS_SUBS,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 4}},
+	},
+	61: { // 0B 5d
+		mask: 0xFFF0000000000000, value: 0x0B50000000000000, length: 2, complete: true,
+		opcode: opcode.Inc, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_INC_DEC,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	62: { // 0B Dd
+		mask: 0xFFF0000000000000, value: 0x0BD0000000000000, length: 2, complete: true,
+		opcode: opcode.Inc, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_INC_DEC,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	63: { // 0B 7[0ddd]
+		mask: 0xFFF8000000000000, value: 0x0B70000000000000, length: 2, complete: true,
+		opcode: opcode.Inc, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_INC_DEC,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	64: { // 0B F[0ddd]
+		mask: 0xFFF8000000000000, value: 0x0BF0000000000000, length: 2, complete: true,
+		opcode: opcode.Inc, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_INC_DEC,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	65: { // 0F 0d
+		mask: 0xFFF0000000000000, value: 0x0F00000000000000, length: 2, complete: true,
+		opcode: opcode.Daa, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8,
+		fields: []field{{role: regDst, start: 12, width: 4}},
+	},
+	66: { // 0F s{1...}[0ddd]
+		mask: 0xFF88000000000000, value: 0x0F80000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R32_R32_S2,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 13, width: 3}},
+	},
+	67: { // 10 0d
+		mask: 0xFFF0000000000000, value: 0x1000000000000000, length: 2, complete: true,
+		opcode: opcode.Shll, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	68: { // 10 4d
+		mask: 0xFFF0000000000000, value: 0x1040000000000000, length: 2, complete: true,
+		opcode: opcode.Shll, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	69: { // 10 1d
+		mask: 0xFFF0000000000000, value: 0x1010000000000000, length: 2, complete: true,
+		opcode: opcode.Shll, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	70: { // 10 5d
+		mask: 0xFFF0000000000000, value: 0x1050000000000000, length: 2, complete: true,
+		opcode: opcode.Shll, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	71: { // 10 3[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1030000000000000, length: 2, complete: true,
+		opcode: opcode.Shll, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	72: { // 10 7[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1070000000000000, length: 2, complete: true,
+		opcode: opcode.Shll, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	73: { // 10 8d
+		mask: 0xFFF0000000000000, value: 0x1080000000000000, length: 2, complete: true,
+		opcode: opcode.Shal, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	74: { // 10 Cd
+		mask: 0xFFF0000000000000, value: 0x10C0000000000000, length: 2, complete: true,
+		opcode: opcode.Shal, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	75: { // 10 9d
+		mask: 0xFFF0000000000000, value: 0x1090000000000000, length: 2, complete: true,
+		opcode: opcode.Shal, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	76: { // 10 Dd
+		mask: 0xFFF0000000000000, value: 0x10D0000000000000, length: 2, complete: true,
+		opcode: opcode.Shal, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	77: { // 10 B[0ddd]
+		mask: 0xFFF8000000000000, value: 0x10B0000000000000, length: 2, complete: true,
+		opcode: opcode.Shal, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	78: { // 10 F[0ddd]
+		mask: 0xFFF8000000000000, value: 0x10F0000000000000, length: 2, complete: true,
+		opcode: opcode.Shal, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	79: { // 11 0d
+		mask: 0xFFF0000000000000, value: 0x1100000000000000, length: 2, complete: true,
+		opcode: opcode.Shlr, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	80: { // 11 4d
+		mask: 0xFFF0000000000000, value: 0x1140000000000000, length: 2, complete: true,
+		opcode: opcode.Shlr, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	81: { // 11 1d
+		mask: 0xFFF0000000000000, value: 0x1110000000000000, length: 2, complete: true,
+		opcode: opcode.Shlr, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	82: { // 11 5d
+		mask: 0xFFF0000000000000, value: 0x1150000000000000, length: 2, complete: true,
+		opcode: opcode.Shlr, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	83: { // 11 3[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1130000000000000, length: 2, complete: true,
+		opcode: opcode.Shlr, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	84: { // 11 7[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1170000000000000, length: 2, complete: true,
+		opcode: opcode.Shlr, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	85: { // 11 8d
+		mask: 0xFFF0000000000000, value: 0x1180000000000000, length: 2, complete: true,
+		opcode: opcode.Shar, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	86: { // 11 Cd
+		mask: 0xFFF0000000000000, value: 0x11C0000000000000, length: 2, complete: true,
+		opcode: opcode.Shar, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	87: { // 11 9d
+		mask: 0xFFF0000000000000, value: 0x1190000000000000, length: 2, complete: true,
+		opcode: opcode.Shar, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	88: { // 11 Dd
+		mask: 0xFFF0000000000000, value: 0x11D0000000000000, length: 2, complete: true,
+		opcode: opcode.Shar, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	89: { // 11 B[0ddd]
+		mask: 0xFFF8000000000000, value: 0x11B0000000000000, length: 2, complete: true,
+		opcode: opcode.Shar, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	90: { // 11 F[0ddd]
+		mask: 0xFFF8000000000000, value: 0x11F0000000000000, length: 2, complete: true,
+		opcode: opcode.Shar, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	91: { // 12 0d
+		mask: 0xFFF0000000000000, value: 0x1200000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxl, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	92: { // 12 4d
+		mask: 0xFFF0000000000000, value: 0x1240000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxl, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	93: { // 12 1d
+		mask: 0xFFF0000000000000, value: 0x1210000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxl, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	94: { // 12 5d
+		mask: 0xFFF0000000000000, value: 0x1250000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxl, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	95: { // 12 3[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1230000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxl, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	96: { // 12 7[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1270000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxl, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	97: { // 12 8d
+		mask: 0xFFF0000000000000, value: 0x1280000000000000, length: 2, complete: true,
+		opcode: opcode.Rotl, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	98: { // 12 Cd
+		mask: 0xFFF0000000000000, value: 0x12C0000000000000, length: 2, complete: true,
+		opcode: opcode.Rotl, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	99: { // 12 9d
+		mask: 0xFFF0000000000000, value: 0x1290000000000000, length: 2, complete: true,
+		opcode: opcode.Rotl, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	100: { // 12 Dd
+		mask: 0xFFF0000000000000, value: 0x12D0000000000000, length: 2, complete: true,
+		opcode: opcode.Rotl, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	101: { // 12 B[0ddd]
+		mask: 0xFFF8000000000000, value: 0x12B0000000000000, length: 2, complete: true,
+		opcode: opcode.Rotl, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	102: { // 12 F[0ddd]
+		mask: 0xFFF8000000000000, value: 0x12F0000000000000, length: 2, complete: true,
+		opcode: opcode.Rotl, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	103: { // 13 0d
+		mask: 0xFFF0000000000000, value: 0x1300000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxr, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	104: { // 13 4d
+		mask: 0xFFF0000000000000, value: 0x1340000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxr, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	105: { // 13 1d
+		mask: 0xFFF0000000000000, value: 0x1310000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxr, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	106: { // 13 5d
+		mask: 0xFFF0000000000000, value: 0x1350000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxr, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	107: { // 13 3[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1330000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxr, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	108: { // 13 7[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1370000000000000, length: 2, complete: true,
+		opcode: opcode.Rotxr, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	109: { // 13 8d
+		mask: 0xFFF0000000000000, value: 0x1380000000000000, length: 2, complete: true,
+		opcode: opcode.Rotr, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	110: { // 13 Cd
+		mask: 0xFFF0000000000000, value: 0x13C0000000000000, length: 2, complete: true,
+		opcode: opcode.Rotr, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	111: { // 13 9d
+		mask: 0xFFF0000000000000, value: 0x1390000000000000, length: 2, complete: true,
+		opcode: opcode.Rotr, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	112: { // 13 Dd
+		mask: 0xFFF0000000000000, value: 0x13D0000000000000, length: 2, complete: true,
+		opcode: opcode.Rotr, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_SH,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	113: { // 13 B[0ddd]
+		mask: 0xFFF8000000000000, value: 0x13B0000000000000, length: 2, complete: true,
+		opcode: opcode.Rotr, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	114: { // 13 F[0ddd]
+		mask: 0xFFF8000000000000, value: 0x13F0000000000000, length: 2, complete: true,
+		opcode: opcode.Rotr, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_SH,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	115: { // 14 sd
+		mask: 0xFF00000000000000, value: 0x1400000000000000, length: 2, complete: true,
+		opcode: opcode.Or, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	116: { // 15 sd
+		mask: 0xFF00000000000000, value: 0x1500000000000000, length: 2, complete: true,
+		opcode: opcode.Xor, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	117: { // 16 sd
+		mask: 0xFF00000000000000, value: 0x1600000000000000, length: 2, complete: true,
+		opcode: opcode.And, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	118: { // 17 0d
+		mask: 0xFFF0000000000000, value: 0x1700000000000000, length: 2, complete: true,
+		opcode: opcode.Not, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8,
+		fields: []field{{role: regDst, start: 12, width: 4}},
+	},
+	119: { // 17 1d
+		mask: 0xFFF0000000000000, value: 0x1710000000000000, length: 2, complete: true,
+		opcode: opcode.Not, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16,
+		fields: []field{{role: regDst, start: 12, width: 4}},
+	},
+	120: { // 17 3[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1730000000000000, length: 2, complete: true,
+		opcode: opcode.Not, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R32,
+		fields: []field{{role: regDst, start: 13, width: 3}},
+	},
+	121: { // 17 5d
+		mask: 0xFFF0000000000000, value: 0x1750000000000000, length: 2, complete: true,
+		opcode: opcode.Extu, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16,
+		fields: []field{{role: regDst, start: 12, width: 4}},
+	},
+	122: { // 17 7[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1770000000000000, length: 2, complete: true,
+		opcode: opcode.Extu, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R32,
+		fields: []field{{role: regDst, start: 13, width: 3}},
+	},
+	123: { // 17 8d
+		mask: 0xFFF0000000000000, value: 0x1780000000000000, length: 2, complete: true,
+		opcode: opcode.Neg, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8,
+		fields: []field{{role: regDst, start: 12, width: 4}},
+	},
+	124: { // 17 9d
+		mask: 0xFFF0000000000000, value: 0x1790000000000000, length: 2, complete: true,
+		opcode: opcode.Neg, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16,
+		fields: []field{{role: regDst, start: 12, width: 4}},
+	},
+	125: { // 17 B[0ddd]
+		mask: 0xFFF8000000000000, value: 0x17B0000000000000, length: 2, complete: true,
+		opcode: opcode.Neg, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R32,
+		fields: []field{{role: regDst, start: 13, width: 3}},
+	},
+	126: { // 17 Dd
+		mask: 0xFFF0000000000000, value: 0x17D0000000000000, length: 2, complete: true,
+		opcode: opcode.Exts, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16,
+		fields: []field{{role: regDst, start: 12, width: 4}},
+	},
+	127: { // 17 F[0ddd]
+		mask: 0xFFF8000000000000, value: 0x17F0000000000000, length: 2, complete: true,
+		opcode: opcode.Exts, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R32,
+		fields: []field{{role: regDst, start: 13, width: 3}},
+	},
+	128: { // 18 sd
+		mask: 0xFF00000000000000, value: 0x1800000000000000, length: 2, complete: true,
+		opcode: opcode.Sub, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	129: { // 19 sd
+		mask: 0xFF00000000000000, value: 0x1900000000000000, length: 2, complete: true,
+		opcode: opcode.Sub, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16_R16,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	130: { // 1A 0d
+		mask: 0xFFF0000000000000, value: 0x1A00000000000000, length: 2, complete: true,
+		opcode: opcode.Dec, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8_INC_DEC,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	131: { // 1A s{1...}[0ddd]
+		mask: 0xFF88000000000000, value: 0x1A80000000000000, length: 2, complete: true,
+		opcode: opcode.Sub, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R32_R32_S2,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 13, width: 3}},
+	},
+	132: { // 1B 0[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1B00000000000000, length: 2, complete: true,
+		opcode: opcode.Subs, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_ADDS_SUBS,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	133: { // 1B 8[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1B80000000000000, length: 2, complete: true,
+		opcode: opcode.Subs, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_ADDS_SUBS,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	134: { // 1B 9[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1B90000000000000, length: 2, complete: true,
+		opcode: opcode.Subs, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_ADDS_SUBS,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 4}},
+	},
+	135: { // 1B 5d
+		mask: 0xFFF0000000000000, value: 0x1B50000000000000, length: 2, complete: true,
+		opcode: opcode.Dec, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_INC_DEC,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 1}},
+	},
+	136: { // 1B Dd
+		mask: 0xFFF0000000000000, value: 0x1BD0000000000000, length: 2, complete: true,
+		opcode: opcode.Dec, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R16_INC_DEC,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, value: 2}},
+	},
+	137: { // 1B 7[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1B70000000000000, length: 2, complete: true,
+		opcode: opcode.Dec, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_INC_DEC,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 1}},
+	},
+	138: { // 1B F[0ddd]
+		mask: 0xFFF8000000000000, value: 0x1BF0000000000000, length: 2, complete: true,
+		opcode: opcode.Dec, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R32_INC_DEC,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, value: 2}},
+	},
+	139: { // 1C sd
+		mask: 0xFF00000000000000, value: 0x1C00000000000000, length: 2, complete: true,
+		opcode: opcode.Cmp, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	140: { // 1D sd
+		mask: 0xFF00000000000000, value: 0x1D00000000000000, length: 2, complete: true,
+		opcode: opcode.Cmp, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16_R16,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	141: { // 1E sd
+		mask: 0xFF00000000000000, value: 0x1E00000000000000, length: 2, complete: true,
+		opcode: opcode.Subx, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	142: { // 1F 0d
+		mask: 0xFFF0000000000000, value: 0x1F00000000000000, length: 2, complete: true,
+		opcode: opcode.Das, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8,
+		fields: []field{{role: regDst, start: 12, width: 4}},
+	},
+	143: { // 1F s{1...}[0ddd]
+		mask: 0xFF88000000000000, value: 0x1F80000000000000, length: 2, complete: true,
+		opcode: opcode.Cmp, bwl: size.Longword, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R32_R32_S2,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 13, width: 3}},
+	},
+	144: { // 2d ii
+		mask: 0xF000000000000000, value: 0x2000000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.AI8_R8,
+		fields: []field{{role: regDst, start: 4, width: 4}, {role: imm, start: 8, width: 8}},
+	},
+	145: { // 3s ii
+		mask: 0xF000000000000000, value: 0x3000000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI8,
+		fields: []field{{role: regSrc, start: 4, width: 4}, {role: imm, start: 8, width: 8}},
+	},
+	146: { // 40 ii
+		mask: 0xFF00000000000000, value: 0x4000000000000000, length: 2, complete: true,
+		opcode: opcode.Bra, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	147: { // 41 ii
+		mask: 0xFF00000000000000, value: 0x4100000000000000, length: 2, complete: true,
+		opcode: opcode.Brn, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	148: { // 42 ii
+		mask: 0xFF00000000000000, value: 0x4200000000000000, length: 2, complete: true,
+		opcode: opcode.Bhi, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	149: { // 43 ii
+		mask: 0xFF00000000000000, value: 0x4300000000000000, length: 2, complete: true,
+		opcode: opcode.Bls, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	150: { // 44 ii
+		mask: 0xFF00000000000000, value: 0x4400000000000000, length: 2, complete: true,
+		opcode: opcode.Bcc, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	151: { // 45 ii
+		mask: 0xFF00000000000000, value: 0x4500000000000000, length: 2, complete: true,
+		opcode: opcode.Bcs, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	152: { // 46 ii
+		mask: 0xFF00000000000000, value: 0x4600000000000000, length: 2, complete: true,
+		opcode: opcode.Bne, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	153: { // 47 ii
+		mask: 0xFF00000000000000, value: 0x4700000000000000, length: 2, complete: true,
+		opcode: opcode.Beq, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	154: { // 48 ii
+		mask: 0xFF00000000000000, value: 0x4800000000000000, length: 2, complete: true,
+		opcode: opcode.Bvc, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	155: { // 49 ii
+		mask: 0xFF00000000000000, value: 0x4900000000000000, length: 2, complete: true,
+		opcode: opcode.Bvs, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	156: { // 4A ii
+		mask: 0xFF00000000000000, value: 0x4A00000000000000, length: 2, complete: true,
+		opcode: opcode.Bpl, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	157: { // 4B ii
+		mask: 0xFF00000000000000, value: 0x4B00000000000000, length: 2, complete: true,
+		opcode: opcode.Bmi, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	158: { // 4C ii
+		mask: 0xFF00000000000000, value: 0x4C00000000000000, length: 2, complete: true,
+		opcode: opcode.Bge, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	159: { // 4D ii
+		mask: 0xFF00000000000000, value: 0x4D00000000000000, length: 2, complete: true,
+		opcode: opcode.Blt, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	160: { // 4E ii
+		mask: 0xFF00000000000000, value: 0x4E00000000000000, length: 2, complete: true,
+		opcode: opcode.Bgt, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	161: { // 4F ii
+		mask: 0xFF00000000000000, value: 0x4F00000000000000, length: 2, complete: true,
+		opcode: opcode.Ble, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 8, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	162: { // 50 sd
+		mask: 0xFF00000000000000, value: 0x5000000000000000, length: 2, complete: true,
+		opcode: opcode.Mulxu, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R16,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	163: { // 51 sd
+		mask: 0xFF00000000000000, value: 0x5100000000000000, length: 2, complete: true,
+		opcode: opcode.Divxu, bwl: size.Byte, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R16,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	164: { // 52 s[0ddd]
+		mask: 0xFF08000000000000, value: 0x5200000000000000, length: 2, complete: true,
+		opcode: opcode.Mulxu, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16_R32,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 13, width: 3}},
+	},
+	165: { // 53 s[0ddd]
+		mask: 0xFF08000000000000, value: 0x5300000000000000, length: 2, complete: true,
+		opcode: opcode.Divxu, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16_R32,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 13, width: 3}},
+	},
+	166: { // 54 70
+		mask: 0xFFFF000000000000, value: 0x5470000000000000, length: 2, complete: true,
+		opcode: opcode.Rts, bwl: size.Unset, mode: addressingmode.None,
+		operandSize: 0, operandType: operand.None,
+	},
+	167: { // 55 ii
+		mask: 0xFF00000000000000, value: 0x5500000000000000, length: 2, complete: true,
+		opcode: opcode.Bsr, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	168: { // 56 70
+		mask: 0xFFFF000000000000, value: 0x5670000000000000, length: 2, complete: true,
+		opcode: opcode.Rte, bwl: size.Unset, mode: addressingmode.None,
+		operandSize: 0, operandType: operand.None,
+	},
+	169: { // 57 [00ii]0
+		mask: 0xFFCF000000000000, value: 0x5700000000000000, length: 2, complete: true,
+		opcode: opcode.Trapa, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.TRAPA_Ix,
+		fields: []field{{role: imm, start: 10, width: 2}},
+	},
+	170: { // 58 00 iiii
+		mask: 0xFFFF000000000000, value: 0x5800000000000000, length: 4, complete: true,
+		opcode: opcode.Bra, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	171: { // 58 10 iiii
+		mask: 0xFFFF000000000000, value: 0x5810000000000000, length: 4, complete: true,
+		opcode: opcode.Brn, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	172: { // 58 20 iiii
+		mask: 0xFFFF000000000000, value: 0x5820000000000000, length: 4, complete: true,
+		opcode: opcode.Bhi, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	173: { // 58 30 iiii
+		mask: 0xFFFF000000000000, value: 0x5830000000000000, length: 4, complete: true,
+		opcode: opcode.Bls, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	174: { // 58 40 iiii
+		mask: 0xFFFF000000000000, value: 0x5840000000000000, length: 4, complete: true,
+		opcode: opcode.Bcc, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	175: { // 58 50 iiii
+		mask: 0xFFFF000000000000, value: 0x5850000000000000, length: 4, complete: true,
+		opcode: opcode.Bcs, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	176: { // 58 60 iiii
+		mask: 0xFFFF000000000000, value: 0x5860000000000000, length: 4, complete: true,
+		opcode: opcode.Bne, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	177: { // 58 70 iiii
+		mask: 0xFFFF000000000000, value: 0x5870000000000000, length: 4, complete: true,
+		opcode: opcode.Beq, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	178: { // 58 80 iiii
+		mask: 0xFFFF000000000000, value: 0x5880000000000000, length: 4, complete: true,
+		opcode: opcode.Bvc, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	179: { // 58 90 iiii
+		mask: 0xFFFF000000000000, value: 0x5890000000000000, length: 4, complete: true,
+		opcode: opcode.Bvs, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	180: { // 58 A0 iiii
+		mask: 0xFFFF000000000000, value: 0x58A0000000000000, length: 4, complete: true,
+		opcode: opcode.Bpl, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	181: { // 58 B0 iiii
+		mask: 0xFFFF000000000000, value: 0x58B0000000000000, length: 4, complete: true,
+		opcode: opcode.Bmi, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	182: { // 58 C0 iiii
+		mask: 0xFFFF000000000000, value: 0x58C0000000000000, length: 4, complete: true,
+		opcode: opcode.Bge, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	183: { // 58 D0 iiii
+		mask: 0xFFFF000000000000, value: 0x58D0000000000000, length: 4, complete: true,
+		opcode: opcode.Blt, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	184: { // 58 E0 iiii
+		mask: 0xFFFF000000000000, value: 0x58E0000000000000, length: 4, complete: true,
+		opcode: opcode.Bgt, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	185: { // 58 F0 iiii
+		mask: 0xFFFF000000000000, value: 0x58F0000000000000, length: 4, complete: true,
+		opcode: opcode.Ble, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 0, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	186: { // 59 [0ddd]0
+		mask: 0xFF8F000000000000, value: 0x5900000000000000, length: 2, complete: true,
+		opcode: opcode.Jmp, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.AR32_S2,
+		fields: []field{{role: regDst, start: 9, width: 3}},
+	},
+	187: { // 5A ii iiii
+		mask: 0xFF00000000000000, value: 0x5A00000000000000, length: 4, complete: true,
+		opcode: opcode.Jmp, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 24, operandType: operand.I24,
+		fields: []field{{role: imm, start: 8, width: 24}},
+	},
+	188: { // 5B ii
+		mask: 0xFF00000000000000, value: 0x5B00000000000000, length: 2, complete: true,
+		opcode: opcode.Jmp, bwl: size.Unset, mode: addressingmode.MemoryIndirect,
+		operandSize: 8, operandType: operand.AAI8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	189: { // 5C 00 iiii
+		mask: 0xFFFF000000000000, value: 0x5C00000000000000, length: 4, complete: true,
+		opcode: opcode.Bsr, bwl: size.Unset, mode: addressingmode.ProgramCounterRelative,
+		operandSize: 16, operandType: operand.O16,
+		fields: []field{{role: imm, start: 16, width: 16}},
+	},
+	190: { // 5D [0ddd]0
+		mask: 0xFF8F000000000000, value: 0x5D00000000000000, length: 2, complete: true,
+		opcode: opcode.Jsr, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.AR32_S2,
+		fields: []field{{role: regDst, start: 9, width: 3}},
+	},
+	191: { // 5E ii iiii
+		mask: 0xFF00000000000000, value: 0x5E00000000000000, length: 4, complete: true,
+		opcode: opcode.Jsr, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 24, operandType: operand.I24,
+		fields: []field{{role: imm, start: 8, width: 24}},
+	},
+	192: { // 5F ii
+		mask: 0xFF00000000000000, value: 0x5F00000000000000, length: 2, complete: true,
+		opcode: opcode.Jsr, bwl: size.Unset, mode: addressingmode.MemoryIndirect,
+		operandSize: 8, operandType: operand.AAI8,
+		fields: []field{{role: imm, start: 8, width: 8}},
+	},
+	193: { // 60 sd
+		mask: 0xFF00000000000000, value: 0x6000000000000000, length: 2, complete: true,
+		opcode: opcode.Bset, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	194: { // 61 sd
+		mask: 0xFF00000000000000, value: 0x6100000000000000, length: 2, complete: true,
+		opcode: opcode.Bnot, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	195: { // 62 sd
+		mask: 0xFF00000000000000, value: 0x6200000000000000, length: 2, complete: true,
+		opcode: opcode.Bclr, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	196: { // 63 sd
+		mask: 0xFF00000000000000, value: 0x6300000000000000, length: 2, complete: true,
+		opcode: opcode.Btst, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R8_R8,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	197: { // 64 sd
+		mask: 0xFF00000000000000, value: 0x6400000000000000, length: 2, complete: true,
+		opcode: opcode.Or, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16_R16,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	198: { // 65 sd
+		mask: 0xFF00000000000000, value: 0x6500000000000000, length: 2, complete: true,
+		opcode: opcode.Xor, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16_R16,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	199: { // 66 sd
+		mask: 0xFF00000000000000, value: 0x6600000000000000, length: 2, complete: true,
+		opcode: opcode.And, bwl: size.Word, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.R16_R16,
+		fields: []field{{role: regSrc, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	200: { // 67 [0iii]d
+		mask: 0xFF80000000000000, value: 0x6700000000000000, length: 2, complete: true,
+		opcode: opcode.Bst, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	201: { // 67 [1iii]d
+		mask: 0xFF80000000000000, value: 0x6780000000000000, length: 2, complete: true,
+		opcode: opcode.Bist, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	202: { // 68 [0sss]d
+		mask: 0xFF80000000000000, value: 0x6800000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.AR32_R8,
+		fields: []field{{role: regSrc, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	203: { // 68 d{1...}s
+		mask: 0xFF80000000000000, value: 0x6880000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.R8_AR32,
+		fields: []field{{role: regDst, start: 8, width: 4}, {role: regSrc, start: 12, width: 4}},
+	},
+	204: { // 69 [0sss]d
+		mask: 0xFF80000000000000, value: 0x6900000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.AR32_R16,
+		fields: []field{{role: regSrc, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	205: { // 69 d{1...}s
+		mask: 0xFF80000000000000, value: 0x6980000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.R16_AR32,
+		fields: []field{{role: regDst, start: 8, width: 4}, {role: regSrc, start: 12, width: 4}},
+	},
+	206: { // 6A 0d iiii
+		mask: 0xFFF0000000000000, value: 0x6A00000000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.AI16_R8,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	207: { // 6A 2d iiii iiii
+		mask: 0xFFF0000000000000, value: 0x6A20000000000000, length: 6, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.AI32_R8,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 32}},
+	},
+	208: { // 6A 4d iiii
+		mask: 0xFFF0000000000000, value: 0x6A40000000000000, length: 4, complete: true,
+		opcode: opcode.Movfpe, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.AI16_R8,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	209: { // 6A 8s iiii
+		mask: 0xFFF0000000000000, value: 0x6A80000000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI16,
+		fields: []field{{role: regSrc, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	210: { // 6A As iiii iiii
+		mask: 0xFFF0000000000000, value: 0x6AA0000000000000, length: 6, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI32,
+		fields: []field{{role: regSrc, start: 12, width: 4}, {role: imm, start: 16, width: 32}},
+	},
+	211: { // 6A Cs iiii
+		mask: 0xFFF0000000000000, value: 0x6AC0000000000000, length: 4, complete: true,
+		opcode: opcode.Movtpe, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI16,
+		fields: []field{{role: regSrc, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	212: { // 6B 0d iiii
+		mask: 0xFFF0000000000000, value: 0x6B00000000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.AbsoluteAddress,
+		operandSize: 16, operandType: operand.AI16_R16,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	213: { // 6B 2d iiii iiii
+		mask: 0xFFF0000000000000, value: 0x6B20000000000000, length: 6, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.AbsoluteAddress,
+		operandSize: 32, operandType: operand.AI32_R16,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 32}},
+	},
+	214: { // 6B 8s iiii
+		mask: 0xFFF0000000000000, value: 0x6B80000000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.AbsoluteAddress,
+		operandSize: 16, operandType: operand.R16_AI16,
+		fields: []field{{role: regSrc, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	215: { // 6B As iiii iiii
+		mask: 0xFFF0000000000000, value: 0x6BA0000000000000, length: 6, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.AbsoluteAddress,
+		operandSize: 32, operandType: operand.R16_AI32,
+		fields: []field{{role: regSrc, start: 12, width: 4}, {role: imm, start: 16, width: 32}},
+	},
+	216: { // 6C [0sss]d
+		mask: 0xFF80000000000000, value: 0x6C00000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.RegisterIndirectWithPostIncrement,
+		operandSize: 0, operandType: operand.AR32_R8,
+		fields: []field{{role: regSrc, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	217: { // 6C d{1...}s
+		mask: 0xFF80000000000000, value: 0x6C80000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.RegisterIndirectWithPreDecrement,
+		operandSize: 0, operandType: operand.R8_AR32,
+		fields: []field{{role: regDst, start: 8, width: 4}, {role: regSrc, start: 12, width: 4}},
+	},
+	218: { // 6D 7d
+		mask: 0xFFF0000000000000, value: 0x6D70000000000000, length: 2, complete: true,
+		opcode: opcode.Pop, bwl: size.Word, mode: addressingmode.RegisterIndirectWithPostIncrement,
+		operandSize: 0, operandType: operand.R16,
+		fields: []field{{role: regDst, start: 12, width: 4}},
+	},
+	219: { // 6D Fd
+		mask: 0xFFF0000000000000, value: 0x6DF0000000000000, length: 2, complete: true,
+		opcode: opcode.Push, bwl: size.Word, mode: addressingmode.RegisterIndirectWithPreDecrement,
+		operandSize: 0, operandType: operand.R16,
+		fields: []field{{role: regDst, start: 12, width: 4}},
+	},
+	220: { // 6D [0sss]d
+		mask: 0xFF80000000000000, value: 0x6D00000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.RegisterIndirectWithPostIncrement,
+		operandSize: 0, operandType: operand.AR32_R16,
+		fields: []field{{role: regSrc, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	221: { // 6D d{1...}s
+		mask: 0xFF80000000000000, value: 0x6D80000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.RegisterIndirectWithPreDecrement,
+		operandSize: 0, operandType: operand.R16_AR32,
+		fields: []field{{role: regDst, start: 8, width: 4}, {role: regSrc, start: 12, width: 4}},
+	},
+	222: { // 6E [0sss]d iiii
+		mask: 0xFF80000000000000, value: 0x6E00000000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.AI16R32_R8,
+		fields: []field{{role: regSrc, start: 9, width: 3}, {role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	223: { // 6E d{1...}s iiii
+		mask: 0xFF80000000000000, value: 0x6E80000000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.R8_AI16R32,
+		fields: []field{{role: regDst, start: 8, width: 4}, {role: regSrc, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	224: { // 6F [0sss]d iiii
+		mask: 0xFF80000000000000, value: 0x6F00000000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 16, operandType: operand.AI16R32_R16,
+		fields: []field{{role: regSrc, start: 9, width: 3}, {role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	225: { // 6F d{1...}s iiii
+		mask: 0xFF80000000000000, value: 0x6F80000000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 16, operandType: operand.R16_AI16R32,
+		fields: []field{{role: regDst, start: 8, width: 4}, {role: regSrc, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	226: { // 6A 10 iiii 63 d-
+		mask: 0xFFFF0000FF000000, value: 0x6A10000063000000, length: 6, complete: true,
+		opcode: opcode.Btst, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI16_S6,
+		fields: []field{{role: imm, start: 16, width: 16}, {role: regDst, start: 40, width: 4}},
+	},
+	227: { // 6A 10 llll 73 m{0...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A10000073000000, length: 6, complete: true,
+		opcode: opcode.Btst, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	228: { // 6A 10 llll 74 m{0...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A10000074000000, length: 6, complete: true,
+		opcode: opcode.Bor, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	229: { // 6A 10 llll 74 m{1...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A10000074800000, length: 6, complete: true,
+		opcode: opcode.Bior, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	230: { // 6A 10 llll 75 m{0...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A10000075000000, length: 6, complete: true,
+		opcode: opcode.Bxor, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	231: { // 6A 10 llll 75 m{1...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A10000075800000, length: 6, complete: true,
+		opcode: opcode.Bixor, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	232: { // 6A 10 llll 76 m{0...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A10000076000000, length: 6, complete: true,
+		opcode: opcode.Band, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	233: { // 6A 10 llll 76 m{1...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A10000076800000, length: 6, complete: true,
+		opcode: opcode.Biand, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	234: { // 6A 10 llll 77 m{0...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A10000077000000, length: 6, complete: true,
+		opcode: opcode.Bld, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	235: { // 6A 10 llll 77 m{1...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A10000077800000, length: 6, complete: true,
+		opcode: opcode.Bild, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	236: { // 6A 18 iiii 60 d-
+		mask: 0xFFFF0000FF000000, value: 0x6A18000060000000, length: 6, complete: true,
+		opcode: opcode.Bset, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI16_S6,
+		fields: []field{{role: imm, start: 16, width: 16}, {role: regDst, start: 40, width: 4}},
+	},
+	237: { // 6A 18 iiii 61 d-
+		mask: 0xFFFF0000FF000000, value: 0x6A18000061000000, length: 6, complete: true,
+		opcode: opcode.Bnot, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI16_S6,
+		fields: []field{{role: imm, start: 16, width: 16}, {role: regDst, start: 40, width: 4}},
+	},
+	238: { // 6A 18 iiii 62 d-
+		mask: 0xFFFF0000FF000000, value: 0x6A18000062000000, length: 6, complete: true,
+		opcode: opcode.Bclr, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI16_S6,
+		fields: []field{{role: imm, start: 16, width: 16}, {role: regDst, start: 40, width: 4}},
+	},
+	239: { // 6A 18 llll 67 m{0...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A18000067000000, length: 6, complete: true,
+		opcode: opcode.Bst, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	240: { // 6A 18 llll 67 m{1...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A18000067800000, length: 6, complete: true,
+		opcode: opcode.Bist, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	241: { // 6A 18 llll 70 m{0...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A18000070000000, length: 6, complete: true,
+		opcode: opcode.Bset, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	242: { // 6A 18 llll 71 m{0...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A18000071000000, length: 6, complete: true,
+		opcode: opcode.Bnot, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	243: { // 6A 18 llll 72 m{0...}-
+		mask: 0xFFFF0000FF800000, value: 0x6A18000072000000, length: 6, complete: true,
+		opcode: opcode.Bclr, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI16,
+		fields: []field{{role: immL, start: 16, width: 16}, {role: immR, start: 40, width: 4}},
+	},
+	244: { // 6A 30 iiii iiii 63 d-
+		mask: 0xFFFF00000000FF00, value: 0x6A30000000006300, length: 8, complete: true,
+		opcode: opcode.Btst, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI32_BCLR,
+		fields: []field{{role: imm, start: 16, width: 32}, {role: regDst, start: 56, width: 4}},
+	},
+	245: { // 6A 30 llll llll 73 m{0...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A30000000007300, length: 8, complete: true,
+		opcode: opcode.Btst, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	246: { // 6A 30 llll llll 74 m{0...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A30000000007400, length: 8, complete: true,
+		opcode: opcode.Bor, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	247: { // 6A 30 llll llll 74 m{1...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A30000000007480, length: 8, complete: true,
+		opcode: opcode.Bior, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	248: { // 6A 30 llll llll 75 m{0...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A30000000007500, length: 8, complete: true,
+		opcode: opcode.Bxor, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	249: { // 6A 30 llll llll 75 m{1...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A30000000007580, length: 8, complete: true,
+		opcode: opcode.Bixor, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	250: { // 6A 30 llll llll 76 m{0...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A30000000007600, length: 8, complete: true,
+		opcode: opcode.Band, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	251: { // 6A 30 llll llll 76 m{1...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A30000000007680, length: 8, complete: true,
+		opcode: opcode.Biand, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	252: { // 6A 30 llll llll 77 m{0...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A30000000007700, length: 8, complete: true,
+		opcode: opcode.Bld, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	253: { // 6A 30 llll llll 77 m{1...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A30000000007780, length: 8, complete: true,
+		opcode: opcode.Bild, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	254: { // 6A 38 iiii iiii 60 d-
+		mask: 0xFFFF00000000FF00, value: 0x6A38000000006000, length: 8, complete: true,
+		opcode: opcode.Bset, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI32_BCLR,
+		fields: []field{{role: imm, start: 16, width: 32}, {role: regDst, start: 56, width: 4}},
+	},
+	255: { // 6A 38 iiii iiii 61 d-
+		mask: 0xFFFF00000000FF00, value: 0x6A38000000006100, length: 8, complete: true,
+		opcode: opcode.Bnot, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI32_BCLR,
+		fields: []field{{role: imm, start: 16, width: 32}, {role: regDst, start: 56, width: 4}},
+	},
+	256: { // 6A 38 iiii iiii 62 d-
+		mask: 0xFFFF00000000FF00, value: 0x6A38000000006200, length: 8, complete: true,
+		opcode: opcode.Bclr, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI32_BCLR,
+		fields: []field{{role: imm, start: 16, width: 32}, {role: regDst, start: 56, width: 4}},
+	},
+	257: { // 6A 38 llll llll 67 m{0...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A38000000006700, length: 8, complete: true,
+		opcode: opcode.Bst, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	258: { // 6A 38 llll llll 67 m{1...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A38000000006780, length: 8, complete: true,
+		opcode: opcode.Bist, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	259: { // 6A 38 llll llll 70 m{0...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A38000000007000, length: 8, complete: true,
+		opcode: opcode.Bset, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	260: { // 6A 38 llll llll 71 m{0...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A38000000007100, length: 8, complete: true,
+		opcode: opcode.Bnot, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	261: { // 6A 38 llll llll 72 m{0...}-
+		mask: 0xFFFF00000000FF80, value: 0x6A38000000007200, length: 8, complete: true,
+		opcode: opcode.Bclr, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI32,
+		fields: []field{{role: immL, start: 16, width: 32}, {role: immR, start: 56, width: 4}},
+	},
+	262: { // 70 i{0...}d
+		mask: 0xFF80000000000000, value: 0x7000000000000000, length: 2, complete: true,
+		opcode: opcode.Bset, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	263: { // 71 i{0...}d
+		mask: 0xFF80000000000000, value: 0x7100000000000000, length: 2, complete: true,
+		opcode: opcode.Bnot, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	264: { // 72 i{0...}d
+		mask: 0xFF80000000000000, value: 0x7200000000000000, length: 2, complete: true,
+		opcode: opcode.Bclr, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	265: { // 73 i{0...}d
+		mask: 0xFF80000000000000, value: 0x7300000000000000, length: 2, complete: true,
+		opcode: opcode.Btst, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	266: { // 74 i{0...}d
+		mask: 0xFF80000000000000, value: 0x7400000000000000, length: 2, complete: true,
+		opcode: opcode.Bor, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	267: { // 74 [1iii]d
+		mask: 0xFF80000000000000, value: 0x7480000000000000, length: 2, complete: true,
+		opcode: opcode.Bior, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	268: { // 75 i{0...}d
+		mask: 0xFF80000000000000, value: 0x7500000000000000, length: 2, complete: true,
+		opcode: opcode.Bxor, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 8, width: 4}, {role: regDst, start: 12, width: 4}},
+	},
+	269: { // 75 [1iii]d
+		mask: 0xFF80000000000000, value: 0x7580000000000000, length: 2, complete: true,
+		opcode: opcode.Bixor, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	270: { // 76 [0iii]d
+		mask: 0xFF80000000000000, value: 0x7600000000000000, length: 2, complete: true,
+		opcode: opcode.Band, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	271: { // 76 [1iii]d
+		mask: 0xFF80000000000000, value: 0x7680000000000000, length: 2, complete: true,
+		opcode: opcode.Biand, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	272: { // 77 [0iii]d
+		mask: 0xFF80000000000000, value: 0x7700000000000000, length: 2, complete: true,
+		opcode: opcode.Bld, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	273: { // 77 [1iii]d
+		mask: 0xFF80000000000000, value: 0x7780000000000000, length: 2, complete: true,
+		opcode: opcode.Bild, bwl: size.Unset, mode: addressingmode.RegisterDirect,
+		operandSize: 0, operandType: operand.Ix_R8,
+		fields: []field{{role: imm, start: 9, width: 3}, {role: regDst, start: 12, width: 4}},
+	},
+	274: { // 78 [0sss]0 6A 2d iiii iiii
+		mask: 0xFF8FFFF000000000, value: 0x78006A2000000000, length: 8, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.AI32R32_R8,
+		fields: []field{{role: regSrc, start: 9, width: 3}, {role: regDst, start: 28, width: 4}, {role: imm, start: 32, width: 32}},
+	},
+	275: { // 78 [0ddd]0 6A As iiii iiii
+		mask: 0xFF8FFFF000000000, value: 0x78006AA000000000, length: 8, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.R8_AI32R32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: regSrc, start: 28, width: 4}, {role: imm, start: 32, width: 32}},
+	},
+	276: { // 78 [0sss]0 6B 2d iiii iiii
+		mask: 0xFF8FFFF000000000, value: 0x78006B2000000000, length: 8, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.AI32R32_R16,
+		fields: []field{{role: regSrc, start: 9, width: 3}, {role: regDst, start: 28, width: 4}, {role: imm, start: 32, width: 32}},
+	},
+	277: { // 78 [0ddd]0 6B As iiii iiii
+		mask: 0xFF8FFFF000000000, value: 0x78006BA000000000, length: 8, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.RegisterIndirectWithDisplacement,
+		operandSize: 0, operandType: operand.R16_AI32R32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: regSrc, start: 28, width: 4}, {role: imm, start: 32, width: 32}},
+	},
+	278: { // 79 0d iiii
+		mask: 0xFFF0000000000000, value: 0x7900000000000000, length: 4, complete: true,
+		opcode: opcode.Mov, bwl: size.Word, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I16_R16,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	279: { // 79 1d iiii
+		mask: 0xFFF0000000000000, value: 0x7910000000000000, length: 4, complete: true,
+		opcode: opcode.Add, bwl: size.Word, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I16_R16,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	280: { // 79 2d iiii
+		mask: 0xFFF0000000000000, value: 0x7920000000000000, length: 4, complete: true,
+		opcode: opcode.Cmp, bwl: size.Word, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I16_R16,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	281: { // 79 3d iiii
+		mask: 0xFFF0000000000000, value: 0x7930000000000000, length: 4, complete: true,
+		opcode: opcode.Sub, bwl: size.Word, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I16_R16,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	282: { // 79 4d iiii
+		mask: 0xFFF0000000000000, value: 0x7940000000000000, length: 4, complete: true,
+		opcode: opcode.Or, bwl: size.Word, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I16_R16,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	283: { // 79 5d iiii
+		mask: 0xFFF0000000000000, value: 0x7950000000000000, length: 4, complete: true,
+		opcode: opcode.Xor, bwl: size.Word, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I16_R16,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	284: { // 79 6d iiii
+		mask: 0xFFF0000000000000, value: 0x7960000000000000, length: 4, complete: true,
+		opcode: opcode.And, bwl: size.Word, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I16_R16,
+		fields: []field{{role: regDst, start: 12, width: 4}, {role: imm, start: 16, width: 16}},
+	},
+	285: { // 7A 0[0ddd] iiii iiii
+		mask: 0xFFF8000000000000, value: 0x7A00000000000000, length: 6, complete: true,
+		opcode: opcode.Mov, bwl: size.Longword, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I32_R32,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, start: 16, width: 32}},
+	},
+	286: { // 7A 1[0ddd] iiii iiii
+		mask: 0xFFF8000000000000, value: 0x7A10000000000000, length: 6, complete: true,
+		opcode: opcode.Add, bwl: size.Longword, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I32_R32,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, start: 16, width: 32}},
+	},
+	287: { // 7A 2[0ddd] iiii iiii
+		mask: 0xFFF8000000000000, value: 0x7A20000000000000, length: 6, complete: true,
+		opcode: opcode.Cmp, bwl: size.Longword, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I32_R32,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, start: 16, width: 32}},
+	},
+	288: { // 7A 3[0ddd] iiii iiii
+		mask: 0xFFF8000000000000, value: 0x7A30000000000000, length: 6, complete: true,
+		opcode: opcode.Sub, bwl: size.Longword, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I32_R32,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, start: 16, width: 32}},
+	},
+	289: { // 7A 4[0ddd] iiii iiii
+		mask: 0xFFF8000000000000, value: 0x7A40000000000000, length: 6, complete: true,
+		opcode: opcode.Or, bwl: size.Longword, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I32_R32,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, start: 16, width: 32}},
+	},
+	290: { // 7A 5[0ddd] iiii iiii
+		mask: 0xFFF8000000000000, value: 0x7A50000000000000, length: 6, complete: true,
+		opcode: opcode.Xor, bwl: size.Longword, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I32_R32,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, start: 16, width: 32}},
+	},
+	291: { // 7A 6[0ddd] iiii iiii
+		mask: 0xFFF8000000000000, value: 0x7A60000000000000, length: 6, complete: true,
+		opcode: opcode.And, bwl: size.Longword, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I32_R32,
+		fields: []field{{role: regDst, start: 13, width: 3}, {role: imm, start: 16, width: 32}},
+	},
+	292: { // 7B 5C 59 8F
+		mask: 0xFFFFFFFF00000000, value: 0x7B5C598F00000000, length: 4, complete: true,
+		opcode: opcode.Eepmov, bwl: size.Byte, mode: addressingmode.None,
+		operandSize: 0, operandType: operand.None,
+	},
+	293: { // 7B D4 59 8F
+		mask: 0xFFFFFFFF00000000, value: 0x7BD4598F00000000, length: 4, complete: true,
+		opcode: opcode.Eepmov, bwl: size.Word, mode: addressingmode.None,
+		operandSize: 0, operandType: operand.None,
+	},
+	294: { // 7C [0ddd]0 63 s-
+		mask: 0xFF8FFF0000000000, value: 0x7C00630000000000, length: 4, complete: true,
+		opcode: opcode.Btst, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.R8_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: regSrc, start: 24, width: 4}},
+	},
+	295: { // 7C [0ddd]0 73 i{0...}-
+		mask: 0xFF8FFF8000000000, value: 0x7C00730000000000, length: 4, complete: true,
+		opcode: opcode.Btst, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 24, width: 4}},
+	},
+	296: { // 7C [0ddd]0 74 [0iii]-
+		mask: 0xFF8FFF8000000000, value: 0x7C00740000000000, length: 4, complete: true,
+		opcode: opcode.Bor, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 25, width: 3}},
+	},
+	297: { // 7C [0ddd]0 74 [1iii]-
+		mask: 0xFF8FFF8000000000, value: 0x7C00748000000000, length: 4, complete: true,
+		opcode: opcode.Bior, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 25, width: 3}},
+	},
+	298: { // 7C [0ddd]0 75 [0iii]-
+		mask: 0xFF8FFF8000000000, value: 0x7C00750000000000, length: 4, complete: true,
+		opcode: opcode.Bxor, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 25, width: 3}},
+	},
+	299: { // 7C [0ddd]0 75 [1iii]-
+		mask: 0xFF8FFF8000000000, value: 0x7C00758000000000, length: 4, complete: true,
+		opcode: opcode.Bixor, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 25, width: 3}},
+	},
+	300: { // 7C [0ddd]0 76 [0iii]-
+		mask: 0xFF8FFF8000000000, value: 0x7C00760000000000, length: 4, complete: true,
+		opcode: opcode.Band, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 25, width: 3}},
+	},
+	301: { // 7C [0ddd]0 76 [1iii]-
+		mask: 0xFF8FFF8000000000, value: 0x7C00768000000000, length: 4, complete: true,
+		opcode: opcode.Biand, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 25, width: 3}},
+	},
+	302: { // 7C [0ddd]0 77 [0iii]-
+		mask: 0xFF8FFF8000000000, value: 0x7C00770000000000, length: 4, complete: true,
+		opcode: opcode.Bld, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 25, width: 3}},
+	},
+	303: { // 7C [0ddd]0 77 [1iii]-
+		mask: 0xFF8FFF8000000000, value: 0x7C00778000000000, length: 4, complete: true,
+		opcode: opcode.Bild, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 25, width: 3}},
+	},
+	304: { // 7D [0ddd]0 60 s-
+		mask: 0xFF8FFF0000000000, value: 0x7D00600000000000, length: 4, complete: true,
+		opcode: opcode.Bset, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.R8_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: regSrc, start: 24, width: 4}},
+	},
+	305: { // 7D [0ddd]0 61 s-
+		mask: 0xFF8FFF0000000000, value: 0x7D00610000000000, length: 4, complete: true,
+		opcode: opcode.Bnot, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.R8_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: regSrc, start: 24, width: 4}},
+	},
+	306: { // 7D [0ddd]0 62 s-
+		mask: 0xFF8FFF0000000000, value: 0x7D00620000000000, length: 4, complete: true,
+		opcode: opcode.Bclr, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.R8_AR32_BCLR,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: regSrc, start: 24, width: 4}},
+	},
+	307: { // 7D [0ddd]0 67 [0iii]-
+		mask: 0xFF8FFF8000000000, value: 0x7D00670000000000, length: 4, complete: true,
+		opcode: opcode.Bst, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 25, width: 3}},
+	},
+	308: { // 7D [0ddd]0 67 [1iii]-
+		mask: 0xFF8FFF8000000000, value: 0x7D00678000000000, length: 4, complete: true,
+		opcode: opcode.Bist, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 25, width: 3}},
+	},
+	309: { // 7D [0ddd]0 70 i{0...}-
+		mask: 0xFF8FFF8000000000, value: 0x7D00700000000000, length: 4, complete: true,
+		opcode: opcode.Bset, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 24, width: 4}},
+	},
+	310: { // 7D [0ddd]0 71 i{0...}-
+		mask: 0xFF8FFF8000000000, value: 0x7D00710000000000, length: 4, complete: true,
+		opcode: opcode.Bnot, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 24, width: 4}},
+	},
+	311: { // 7D [0ddd]0 72 i{0...}-
+		mask: 0xFF8FFF8000000000, value: 0x7D00720000000000, length: 4, complete: true,
+		opcode: opcode.Bclr, bwl: size.Unset, mode: addressingmode.RegisterIndirect,
+		operandSize: 0, operandType: operand.Ix_AR32,
+		fields: []field{{role: regDst, start: 9, width: 3}, {role: imm, start: 24, width: 4}},
+	},
+	312: { // 7E ii 63 d0
+		mask: 0xFF00FF0F00000000, value: 0x7E00630000000000, length: 4, complete: true,
+		opcode: opcode.Btst, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI8_BCLR,
+		fields: []field{{role: imm, start: 8, width: 8}, {role: regDst, start: 24, width: 4}},
+	},
+	313: { // 7E ll 73 m{0...}-
+		mask: 0xFF00FF8000000000, value: 0x7E00730000000000, length: 4, complete: true,
+		opcode: opcode.Btst, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	314: { // 7E ll 74 m{0...}-
+		mask: 0xFF00FF8000000000, value: 0x7E00740000000000, length: 4, complete: true,
+		opcode: opcode.Bor, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	315: { // 7E ll 74 m{1...}-
+		mask: 0xFF00FF8000000000, value: 0x7E00748000000000, length: 4, complete: true,
+		opcode: opcode.Bior, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	316: { // 7E ll 75 m{0...}-
+		mask: 0xFF00FF8000000000, value: 0x7E00750000000000, length: 4, complete: true,
+		opcode: opcode.Bxor, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	317: { // 7E ll 75 m{1...}-
+		mask: 0xFF00FF8000000000, value: 0x7E00758000000000, length: 4, complete: true,
+		opcode: opcode.Bixor, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	318: { // 7E ll 76 m{0...}-
+		mask: 0xFF00FF8000000000, value: 0x7E00760000000000, length: 4, complete: true,
+		opcode: opcode.Band, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	319: { // 7E ll 76 m{1...}-
+		mask: 0xFF00FF8000000000, value: 0x7E00768000000000, length: 4, complete: true,
+		opcode: opcode.Biand, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	320: { // 7E ll 77 m{0...}-
+		mask: 0xFF00FF8000000000, value: 0x7E00770000000000, length: 4, complete: true,
+		opcode: opcode.Bld, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	321: { // 7E ll 77 m{1...}-
+		mask: 0xFF00FF8000000000, value: 0x7E00778000000000, length: 4, complete: true,
+		opcode: opcode.Bild, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	322: { // 7F ii 60 d-
+		mask: 0xFF00FF0000000000, value: 0x7F00600000000000, length: 4, complete: true,
+		opcode: opcode.Bset, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI8_BCLR,
+		fields: []field{{role: imm, start: 8, width: 8}, {role: regDst, start: 24, width: 4}},
+	},
+	323: { // 7F ii 61 d-
+		mask: 0xFF00FF0000000000, value: 0x7F00610000000000, length: 4, complete: true,
+		opcode: opcode.Bnot, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI8_BCLR,
+		fields: []field{{role: imm, start: 8, width: 8}, {role: regDst, start: 24, width: 4}},
+	},
+	324: { // 7F ii 62 d-
+		mask: 0xFF00FF0000000000, value: 0x7F00620000000000, length: 4, complete: true,
+		opcode: opcode.Bclr, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.R8_AI8_BCLR,
+		fields: []field{{role: imm, start: 8, width: 8}, {role: regDst, start: 24, width: 4}},
+	},
+	325: { // 7F ll 67 m{0...}-
+		mask: 0xFF00FF8000000000, value: 0x7F00670000000000, length: 4, complete: true,
+		opcode: opcode.Bst, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	326: { // 7F ll 67 m{1...}-
+		mask: 0xFF00FF8000000000, value: 0x7F00678000000000, length: 4, complete: true,
+		opcode: opcode.Bist, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	327: { // 7F ll 70 m{0...}-
+		mask: 0xFF00FF8000000000, value: 0x7F00700000000000, length: 4, complete: true,
+		opcode: opcode.Bset, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	328: { // 7F ll 71 m{0...}-
+		mask: 0xFF00FF8000000000, value: 0x7F00710000000000, length: 4, complete: true,
+		opcode: opcode.Bnot, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	329: { // 7F ll 72 m{0...}-
+		mask: 0xFF00FF8000000000, value: 0x7F00720000000000, length: 4, complete: true,
+		opcode: opcode.Bclr, bwl: size.Unset, mode: addressingmode.AbsoluteAddress,
+		operandSize: 0, operandType: operand.Ix_AI8,
+		fields: []field{{role: immL, start: 8, width: 8}, {role: immR, start: 24, width: 4}},
+	},
+	330: { // 8d ii
+		mask: 0xF000000000000000, value: 0x8000000000000000, length: 2, complete: true,
+		opcode: opcode.Add, bwl: size.Byte, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_R8,
+		fields: []field{{role: regDst, start: 4, width: 4}, {role: imm, start: 8, width: 8}},
+	},
+	331: { // 9d ii
+		mask: 0xF000000000000000, value: 0x9000000000000000, length: 2, complete: true,
+		opcode: opcode.Addx, bwl: size.Unset, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_R8,
+		fields: []field{{role: regDst, start: 4, width: 4}, {role: imm, start: 8, width: 8}},
+	},
+	332: { // Ad ii
+		mask: 0xF000000000000000, value: 0xA000000000000000, length: 2, complete: true,
+		opcode: opcode.Cmp, bwl: size.Byte, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_R8,
+		fields: []field{{role: regDst, start: 4, width: 4}, {role: imm, start: 8, width: 8}},
+	},
+	333: { // Bd ii
+		mask: 0xF000000000000000, value: 0xB000000000000000, length: 2, complete: true,
+		opcode: opcode.Subx, bwl: size.Unset, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_R8,
+		fields: []field{{role: regDst, start: 4, width: 4}, {role: imm, start: 8, width: 8}},
+	},
+	334: { // Cd ii
+		mask: 0xF000000000000000, value: 0xC000000000000000, length: 2, complete: true,
+		opcode: opcode.Or, bwl: size.Byte, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_R8,
+		fields: []field{{role: regDst, start: 4, width: 4}, {role: imm, start: 8, width: 8}},
+	},
+	335: { // Dd ii
+		mask: 0xF000000000000000, value: 0xD000000000000000, length: 2, complete: true,
+		opcode: opcode.Xor, bwl: size.Byte, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_R8,
+		fields: []field{{role: regDst, start: 4, width: 4}, {role: imm, start: 8, width: 8}},
+	},
+	336: { // Ed ii
+		mask: 0xF000000000000000, value: 0xE000000000000000, length: 2, complete: true,
+		opcode: opcode.And, bwl: size.Byte, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_R8,
+		fields: []field{{role: regDst, start: 4, width: 4}, {role: imm, start: 8, width: 8}},
+	},
+	337: { // Fd ii
+		mask: 0xF000000000000000, value: 0xF000000000000000, length: 2, complete: true,
+		opcode: opcode.Mov, bwl: size.Byte, mode: addressingmode.Immediate,
+		operandSize: 0, operandType: operand.I8_R8,
+		fields: []field{{role: regDst, start: 4, width: 4}, {role: imm, start: 8, width: 8}},
+	},
+}
+
+// lookup returns the index in forms of the first form matching the
+// instruction starting with the 8 bytes in w, or -1 if there isn't one.
+func lookup(w uint64) int {
+	switch w >> 56 {
+	case 0x00:
+		if w&0x00FF000000000000 == 0x0000000000000000 {
+			return 0
+		}
+	case 0x01:
+		if w&0x00FFFF8800000000 == 0x0000690000000000 {
+			return 12
+		}
+		if w&0x00FFFF8800000000 == 0x0000698000000000 {
+			return 13
+		}
+		if w&0x00FFFF8800000000 == 0x00006F0000000000 {
+			return 14
+		}
+		if w&0x00FFFF8800000000 == 0x00006F8000000000 {
+			return 15
+		}
+		if w&0x00FFFF8FFFF80000 == 0x000078006B200000 {
+			return 16
+		}
+		if w&0x00FFFF8FFFF80000 == 0x000078006BA00000 {
+			return 17
+		}
+		if w&0x00FFFFF800000000 == 0x00006B0000000000 {
+			return 18
+		}
+		if w&0x00FFFFF800000000 == 0x00006B8000000000 {
+			return 19
+		}
+		if w&0x00FFFFF800000000 == 0x00006B2000000000 {
+			return 20
+		}
+		if w&0x00FFFFF800000000 == 0x00006BA000000000 {
+			return 21
+		}
+		if w&0x00FFFFF800000000 == 0x00006D7000000000 {
+			return 22
+		}
+		if w&0x00FFFF8800000000 == 0x00006D0000000000 {
+			return 23
+		}
+		if w&0x00FFFFF800000000 == 0x00006DF000000000 {
+			return 24
+		}
+		if w&0x00FFFFF800000000 == 0x00106D7000000000 {
+			return 25
+		}
+		if w&0x00FFFFF800000000 == 0x00206D7000000000 {
+			return 26
+		}
+		if w&0x00FFFFF800000000 == 0x00306D7000000000 {
+			return 27
+		}
+		if w&0x00FFFFF800000000 == 0x00106DF000000000 {
+			return 28
+		}
+		if w&0x00FFFFF800000000 == 0x00206DF000000000 {
+			return 29
+		}
+		if w&0x00FFFFF800000000 == 0x00306DF000000000 {
+			return 30
+		}
+		if w&0x00FEFF8000000000 == 0x0040690000000000 {
+			return 31
+		}
+		if w&0x00FEFF8000000000 == 0x0040698000000000 {
+			return 32
+		}
+		if w&0x00FEFF8000000000 == 0x00406F0000000000 {
+			return 33
+		}
+		if w&0x00FEFF8000000000 == 0x00406F8000000000 {
+			return 34
+		}
+		if w&0x00FEFF80FFFF0000 == 0x004078006B200000 {
+			return 35
+		}
+		if w&0x00FEFF80FFFF0000 == 0x004078006BA00000 {
+			return 36
+		}
+		if w&0x00FEFF8000000000 == 0x00406D8000000000 {
+			return 37
+		}
+		if w&0x00FEFF8000000000 == 0x00406D0000000000 {
+			return 38
+		}
+		if w&0x00FEFFFF00000000 == 0x00406B0000000000 {
+			return 39
+		}
+		if w&0x00FEFFFF00000000 == 0x00406B2000000000 {
+			return 40
+		}
+		if w&0x00FEFFFF00000000 == 0x00406B8000000000 {
+			return 41
+		}
+		if w&0x00FEFFFF00000000 == 0x00406BA000000000 {
+			return 42
+		}
+		if w&0x00FFFF0000000000 == 0x0041040000000000 {
+			return 43
+		}
+		if w&0x00FFFF0000000000 == 0x0041050000000000 {
+			return 44
+		}
+		if w&0x00FFFF0000000000 == 0x0041060000000000 {
+			return 45
+		}
+		if w&0x00FFFF0000000000 == 0x0041070000000000 {
+			return 46
+		}
+		if w&0x00FF000000000000 == 0x0080000000000000 {
+			return 47
+		}
+		if w&0x00FFFF8F00000000 == 0x00E07B0C00000000 {
+			return 48
+		}
+		if w&0x00FFFF0000000000 == 0x00C0500000000000 {
+			return 49
+		}
+		if w&0x00FFFF0800000000 == 0x00C0520000000000 {
+			return 50
+		}
+		if w&0x00FFFF0000000000 == 0x00D0510000000000 {
+			return 51
+		}
+		if w&0x00FFFF0800000000 == 0x00D0530000000000 {
+			return 52
+		}
+		if w&0x00FFFF8800000000 == 0x00F0640000000000 {
+			return 53
+		}
+		if w&0x00FFFF8800000000 == 0x00F0650000000000 {
+			return 54
+		}
+		if w&0x00FFFF8800000000 == 0x00F0660000000000 {
+			return 55
+		}
+	case 0x02:
+		if w&0x00E0000000000000 == 0x0000000000000000 {
+			return 1
+		}
+	case 0x03:
+		if w&0x00E0000000000000 == 0x0000000000000000 {
+			return 2
+		}
+	case 0x04:
+		return 3
+	case 0x05:
+		return 4
+	case 0x06:
+		return 5
+	case 0x07:
+		return 6
+	case 0x08:
+		return 7
+	case 0x09:
+		return 8
+	case 0x0A:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 56
+		}
+		if w&0x0088000000000000 == 0x0080000000000000 {
+			return 57
+		}
+	case 0x0B:
+		if w&0x00F8000000000000 == 0x0000000000000000 {
+			return 58
+		}
+		if w&0x00F8000000000000 == 0x0080000000000000 {
+			return 59
+		}
+		if w&0x00F8000000000000 == 0x0090000000000000 {
+			return 60
+		}
+		if w&0x00F0000000000000 == 0x0050000000000000 {
+			return 61
+		}
+		if w&0x00F0000000000000 == 0x00D0000000000000 {
+			return 62
+		}
+		if w&0x00F8000000000000 == 0x0070000000000000 {
+			return 63
+		}
+		if w&0x00F8000000000000 == 0x00F0000000000000 {
+			return 64
+		}
+	case 0x0C:
+		return 9
+	case 0x0D:
+		return 10
+	case 0x0E:
+		return 11
+	case 0x0F:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 65
+		}
+		if w&0x0088000000000000 == 0x0080000000000000 {
+			return 66
+		}
+	case 0x10:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 67
+		}
+		if w&0x00F0000000000000 == 0x0040000000000000 {
+			return 68
+		}
+		if w&0x00F0000000000000 == 0x0010000000000000 {
+			return 69
+		}
+		if w&0x00F0000000000000 == 0x0050000000000000 {
+			return 70
+		}
+		if w&0x00F8000000000000 == 0x0030000000000000 {
+			return 71
+		}
+		if w&0x00F8000000000000 == 0x0070000000000000 {
+			return 72
+		}
+		if w&0x00F0000000000000 == 0x0080000000000000 {
+			return 73
+		}
+		if w&0x00F0000000000000 == 0x00C0000000000000 {
+			return 74
+		}
+		if w&0x00F0000000000000 == 0x0090000000000000 {
+			return 75
+		}
+		if w&0x00F0000000000000 == 0x00D0000000000000 {
+			return 76
+		}
+		if w&0x00F8000000000000 == 0x00B0000000000000 {
+			return 77
+		}
+		if w&0x00F8000000000000 == 0x00F0000000000000 {
+			return 78
+		}
+	case 0x11:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 79
+		}
+		if w&0x00F0000000000000 == 0x0040000000000000 {
+			return 80
+		}
+		if w&0x00F0000000000000 == 0x0010000000000000 {
+			return 81
+		}
+		if w&0x00F0000000000000 == 0x0050000000000000 {
+			return 82
+		}
+		if w&0x00F8000000000000 == 0x0030000000000000 {
+			return 83
+		}
+		if w&0x00F8000000000000 == 0x0070000000000000 {
+			return 84
+		}
+		if w&0x00F0000000000000 == 0x0080000000000000 {
+			return 85
+		}
+		if w&0x00F0000000000000 == 0x00C0000000000000 {
+			return 86
+		}
+		if w&0x00F0000000000000 == 0x0090000000000000 {
+			return 87
+		}
+		if w&0x00F0000000000000 == 0x00D0000000000000 {
+			return 88
+		}
+		if w&0x00F8000000000000 == 0x00B0000000000000 {
+			return 89
+		}
+		if w&0x00F8000000000000 == 0x00F0000000000000 {
+			return 90
+		}
+	case 0x12:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 91
+		}
+		if w&0x00F0000000000000 == 0x0040000000000000 {
+			return 92
+		}
+		if w&0x00F0000000000000 == 0x0010000000000000 {
+			return 93
+		}
+		if w&0x00F0000000000000 == 0x0050000000000000 {
+			return 94
+		}
+		if w&0x00F8000000000000 == 0x0030000000000000 {
+			return 95
+		}
+		if w&0x00F8000000000000 == 0x0070000000000000 {
+			return 96
+		}
+		if w&0x00F0000000000000 == 0x0080000000000000 {
+			return 97
+		}
+		if w&0x00F0000000000000 == 0x00C0000000000000 {
+			return 98
+		}
+		if w&0x00F0000000000000 == 0x0090000000000000 {
+			return 99
+		}
+		if w&0x00F0000000000000 == 0x00D0000000000000 {
+			return 100
+		}
+		if w&0x00F8000000000000 == 0x00B0000000000000 {
+			return 101
+		}
+		if w&0x00F8000000000000 == 0x00F0000000000000 {
+			return 102
+		}
+	case 0x13:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 103
+		}
+		if w&0x00F0000000000000 == 0x0040000000000000 {
+			return 104
+		}
+		if w&0x00F0000000000000 == 0x0010000000000000 {
+			return 105
+		}
+		if w&0x00F0000000000000 == 0x0050000000000000 {
+			return 106
+		}
+		if w&0x00F8000000000000 == 0x0030000000000000 {
+			return 107
+		}
+		if w&0x00F8000000000000 == 0x0070000000000000 {
+			return 108
+		}
+		if w&0x00F0000000000000 == 0x0080000000000000 {
+			return 109
+		}
+		if w&0x00F0000000000000 == 0x00C0000000000000 {
+			return 110
+		}
+		if w&0x00F0000000000000 == 0x0090000000000000 {
+			return 111
+		}
+		if w&0x00F0000000000000 == 0x00D0000000000000 {
+			return 112
+		}
+		if w&0x00F8000000000000 == 0x00B0000000000000 {
+			return 113
+		}
+		if w&0x00F8000000000000 == 0x00F0000000000000 {
+			return 114
+		}
+	case 0x14:
+		return 115
+	case 0x15:
+		return 116
+	case 0x16:
+		return 117
+	case 0x17:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 118
+		}
+		if w&0x00F0000000000000 == 0x0010000000000000 {
+			return 119
+		}
+		if w&0x00F8000000000000 == 0x0030000000000000 {
+			return 120
+		}
+		if w&0x00F0000000000000 == 0x0050000000000000 {
+			return 121
+		}
+		if w&0x00F8000000000000 == 0x0070000000000000 {
+			return 122
+		}
+		if w&0x00F0000000000000 == 0x0080000000000000 {
+			return 123
+		}
+		if w&0x00F0000000000000 == 0x0090000000000000 {
+			return 124
+		}
+		if w&0x00F8000000000000 == 0x00B0000000000000 {
+			return 125
+		}
+		if w&0x00F0000000000000 == 0x00D0000000000000 {
+			return 126
+		}
+		if w&0x00F8000000000000 == 0x00F0000000000000 {
+			return 127
+		}
+	case 0x18:
+		return 128
+	case 0x19:
+		return 129
+	case 0x1A:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 130
+		}
+		if w&0x0088000000000000 == 0x0080000000000000 {
+			return 131
+		}
+	case 0x1B:
+		if w&0x00F8000000000000 == 0x0000000000000000 {
+			return 132
+		}
+		if w&0x00F8000000000000 == 0x0080000000000000 {
+			return 133
+		}
+		if w&0x00F8000000000000 == 0x0090000000000000 {
+			return 134
+		}
+		if w&0x00F0000000000000 == 0x0050000000000000 {
+			return 135
+		}
+		if w&0x00F0000000000000 == 0x00D0000000000000 {
+			return 136
+		}
+		if w&0x00F8000000000000 == 0x0070000000000000 {
+			return 137
+		}
+		if w&0x00F8000000000000 == 0x00F0000000000000 {
+			return 138
+		}
+	case 0x1C:
+		return 139
+	case 0x1D:
+		return 140
+	case 0x1E:
+		return 141
+	case 0x1F:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 142
+		}
+		if w&0x0088000000000000 == 0x0080000000000000 {
+			return 143
+		}
+	case 0x20:
+		return 144
+	case 0x21:
+		return 144
+	case 0x22:
+		return 144
+	case 0x23:
+		return 144
+	case 0x24:
+		return 144
+	case 0x25:
+		return 144
+	case 0x26:
+		return 144
+	case 0x27:
+		return 144
+	case 0x28:
+		return 144
+	case 0x29:
+		return 144
+	case 0x2A:
+		return 144
+	case 0x2B:
+		return 144
+	case 0x2C:
+		return 144
+	case 0x2D:
+		return 144
+	case 0x2E:
+		return 144
+	case 0x2F:
+		return 144
+	case 0x30:
+		return 145
+	case 0x31:
+		return 145
+	case 0x32:
+		return 145
+	case 0x33:
+		return 145
+	case 0x34:
+		return 145
+	case 0x35:
+		return 145
+	case 0x36:
+		return 145
+	case 0x37:
+		return 145
+	case 0x38:
+		return 145
+	case 0x39:
+		return 145
+	case 0x3A:
+		return 145
+	case 0x3B:
+		return 145
+	case 0x3C:
+		return 145
+	case 0x3D:
+		return 145
+	case 0x3E:
+		return 145
+	case 0x3F:
+		return 145
+	case 0x40:
+		return 146
+	case 0x41:
+		return 147
+	case 0x42:
+		return 148
+	case 0x43:
+		return 149
+	case 0x44:
+		return 150
+	case 0x45:
+		return 151
+	case 0x46:
+		return 152
+	case 0x47:
+		return 153
+	case 0x48:
+		return 154
+	case 0x49:
+		return 155
+	case 0x4A:
+		return 156
+	case 0x4B:
+		return 157
+	case 0x4C:
+		return 158
+	case 0x4D:
+		return 159
+	case 0x4E:
+		return 160
+	case 0x4F:
+		return 161
+	case 0x50:
+		return 162
+	case 0x51:
+		return 163
+	case 0x52:
+		if w&0x0008000000000000 == 0x0000000000000000 {
+			return 164
+		}
+	case 0x53:
+		if w&0x0008000000000000 == 0x0000000000000000 {
+			return 165
+		}
+	case 0x54:
+		if w&0x00FF000000000000 == 0x0070000000000000 {
+			return 166
+		}
+	case 0x55:
+		return 167
+	case 0x56:
+		if w&0x00FF000000000000 == 0x0070000000000000 {
+			return 168
+		}
+	case 0x57:
+		if w&0x00CF000000000000 == 0x0000000000000000 {
+			return 169
+		}
+	case 0x58:
+		if w&0x00FF000000000000 == 0x0000000000000000 {
+			return 170
+		}
+		if w&0x00FF000000000000 == 0x0010000000000000 {
+			return 171
+		}
+		if w&0x00FF000000000000 == 0x0020000000000000 {
+			return 172
+		}
+		if w&0x00FF000000000000 == 0x0030000000000000 {
+			return 173
+		}
+		if w&0x00FF000000000000 == 0x0040000000000000 {
+			return 174
+		}
+		if w&0x00FF000000000000 == 0x0050000000000000 {
+			return 175
+		}
+		if w&0x00FF000000000000 == 0x0060000000000000 {
+			return 176
+		}
+		if w&0x00FF000000000000 == 0x0070000000000000 {
+			return 177
+		}
+		if w&0x00FF000000000000 == 0x0080000000000000 {
+			return 178
+		}
+		if w&0x00FF000000000000 == 0x0090000000000000 {
+			return 179
+		}
+		if w&0x00FF000000000000 == 0x00A0000000000000 {
+			return 180
+		}
+		if w&0x00FF000000000000 == 0x00B0000000000000 {
+			return 181
+		}
+		if w&0x00FF000000000000 == 0x00C0000000000000 {
+			return 182
+		}
+		if w&0x00FF000000000000 == 0x00D0000000000000 {
+			return 183
+		}
+		if w&0x00FF000000000000 == 0x00E0000000000000 {
+			return 184
+		}
+		if w&0x00FF000000000000 == 0x00F0000000000000 {
+			return 185
+		}
+	case 0x59:
+		if w&0x008F000000000000 == 0x0000000000000000 {
+			return 186
+		}
+	case 0x5A:
+		return 187
+	case 0x5B:
+		return 188
+	case 0x5C:
+		if w&0x00FF000000000000 == 0x0000000000000000 {
+			return 189
+		}
+	case 0x5D:
+		if w&0x008F000000000000 == 0x0000000000000000 {
+			return 190
+		}
+	case 0x5E:
+		return 191
+	case 0x5F:
+		return 192
+	case 0x60:
+		return 193
+	case 0x61:
+		return 194
+	case 0x62:
+		return 195
+	case 0x63:
+		return 196
+	case 0x64:
+		return 197
+	case 0x65:
+		return 198
+	case 0x66:
+		return 199
+	case 0x67:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 200
+		}
+		if w&0x0080000000000000 == 0x0080000000000000 {
+			return 201
+		}
+	case 0x68:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 202
+		}
+		if w&0x0080000000000000 == 0x0080000000000000 {
+			return 203
+		}
+	case 0x69:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 204
+		}
+		if w&0x0080000000000000 == 0x0080000000000000 {
+			return 205
+		}
+	case 0x6A:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 206
+		}
+		if w&0x00F0000000000000 == 0x0020000000000000 {
+			return 207
+		}
+		if w&0x00F0000000000000 == 0x0040000000000000 {
+			return 208
+		}
+		if w&0x00F0000000000000 == 0x0080000000000000 {
+			return 209
+		}
+		if w&0x00F0000000000000 == 0x00A0000000000000 {
+			return 210
+		}
+		if w&0x00F0000000000000 == 0x00C0000000000000 {
+			return 211
+		}
+		if w&0x00FF0000FF000000 == 0x0010000063000000 {
+			return 226
+		}
+		if w&0x00FF0000FF800000 == 0x0010000073000000 {
+			return 227
+		}
+		if w&0x00FF0000FF800000 == 0x0010000074000000 {
+			return 228
+		}
+		if w&0x00FF0000FF800000 == 0x0010000074800000 {
+			return 229
+		}
+		if w&0x00FF0000FF800000 == 0x0010000075000000 {
+			return 230
+		}
+		if w&0x00FF0000FF800000 == 0x0010000075800000 {
+			return 231
+		}
+		if w&0x00FF0000FF800000 == 0x0010000076000000 {
+			return 232
+		}
+		if w&0x00FF0000FF800000 == 0x0010000076800000 {
+			return 233
+		}
+		if w&0x00FF0000FF800000 == 0x0010000077000000 {
+			return 234
+		}
+		if w&0x00FF0000FF800000 == 0x0010000077800000 {
+			return 235
+		}
+		if w&0x00FF0000FF000000 == 0x0018000060000000 {
+			return 236
+		}
+		if w&0x00FF0000FF000000 == 0x0018000061000000 {
+			return 237
+		}
+		if w&0x00FF0000FF000000 == 0x0018000062000000 {
+			return 238
+		}
+		if w&0x00FF0000FF800000 == 0x0018000067000000 {
+			return 239
+		}
+		if w&0x00FF0000FF800000 == 0x0018000067800000 {
+			return 240
+		}
+		if w&0x00FF0000FF800000 == 0x0018000070000000 {
+			return 241
+		}
+		if w&0x00FF0000FF800000 == 0x0018000071000000 {
+			return 242
+		}
+		if w&0x00FF0000FF800000 == 0x0018000072000000 {
+			return 243
+		}
+		if w&0x00FF00000000FF00 == 0x0030000000006300 {
+			return 244
+		}
+		if w&0x00FF00000000FF80 == 0x0030000000007300 {
+			return 245
+		}
+		if w&0x00FF00000000FF80 == 0x0030000000007400 {
+			return 246
+		}
+		if w&0x00FF00000000FF80 == 0x0030000000007480 {
+			return 247
+		}
+		if w&0x00FF00000000FF80 == 0x0030000000007500 {
+			return 248
+		}
+		if w&0x00FF00000000FF80 == 0x0030000000007580 {
+			return 249
+		}
+		if w&0x00FF00000000FF80 == 0x0030000000007600 {
+			return 250
+		}
+		if w&0x00FF00000000FF80 == 0x0030000000007680 {
+			return 251
+		}
+		if w&0x00FF00000000FF80 == 0x0030000000007700 {
+			return 252
+		}
+		if w&0x00FF00000000FF80 == 0x0030000000007780 {
+			return 253
+		}
+		if w&0x00FF00000000FF00 == 0x0038000000006000 {
+			return 254
+		}
+		if w&0x00FF00000000FF00 == 0x0038000000006100 {
+			return 255
+		}
+		if w&0x00FF00000000FF00 == 0x0038000000006200 {
+			return 256
+		}
+		if w&0x00FF00000000FF80 == 0x0038000000006700 {
+			return 257
+		}
+		if w&0x00FF00000000FF80 == 0x0038000000006780 {
+			return 258
+		}
+		if w&0x00FF00000000FF80 == 0x0038000000007000 {
+			return 259
+		}
+		if w&0x00FF00000000FF80 == 0x0038000000007100 {
+			return 260
+		}
+		if w&0x00FF00000000FF80 == 0x0038000000007200 {
+			return 261
+		}
+	case 0x6B:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 212
+		}
+		if w&0x00F0000000000000 == 0x0020000000000000 {
+			return 213
+		}
+		if w&0x00F0000000000000 == 0x0080000000000000 {
+			return 214
+		}
+		if w&0x00F0000000000000 == 0x00A0000000000000 {
+			return 215
+		}
+	case 0x6C:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 216
+		}
+		if w&0x0080000000000000 == 0x0080000000000000 {
+			return 217
+		}
+	case 0x6D:
+		if w&0x00F0000000000000 == 0x0070000000000000 {
+			return 218
+		}
+		if w&0x00F0000000000000 == 0x00F0000000000000 {
+			return 219
+		}
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 220
+		}
+		if w&0x0080000000000000 == 0x0080000000000000 {
+			return 221
+		}
+	case 0x6E:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 222
+		}
+		if w&0x0080000000000000 == 0x0080000000000000 {
+			return 223
+		}
+	case 0x6F:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 224
+		}
+		if w&0x0080000000000000 == 0x0080000000000000 {
+			return 225
+		}
+	case 0x70:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 262
+		}
+	case 0x71:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 263
+		}
+	case 0x72:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 264
+		}
+	case 0x73:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 265
+		}
+	case 0x74:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 266
+		}
+		if w&0x0080000000000000 == 0x0080000000000000 {
+			return 267
+		}
+	case 0x75:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 268
+		}
+		if w&0x0080000000000000 == 0x0080000000000000 {
+			return 269
+		}
+	case 0x76:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 270
+		}
+		if w&0x0080000000000000 == 0x0080000000000000 {
+			return 271
+		}
+	case 0x77:
+		if w&0x0080000000000000 == 0x0000000000000000 {
+			return 272
+		}
+		if w&0x0080000000000000 == 0x0080000000000000 {
+			return 273
+		}
+	case 0x78:
+		if w&0x008FFFF000000000 == 0x00006A2000000000 {
+			return 274
+		}
+		if w&0x008FFFF000000000 == 0x00006AA000000000 {
+			return 275
+		}
+		if w&0x008FFFF000000000 == 0x00006B2000000000 {
+			return 276
+		}
+		if w&0x008FFFF000000000 == 0x00006BA000000000 {
+			return 277
+		}
+	case 0x79:
+		if w&0x00F0000000000000 == 0x0000000000000000 {
+			return 278
+		}
+		if w&0x00F0000000000000 == 0x0010000000000000 {
+			return 279
+		}
+		if w&0x00F0000000000000 == 0x0020000000000000 {
+			return 280
+		}
+		if w&0x00F0000000000000 == 0x0030000000000000 {
+			return 281
+		}
+		if w&0x00F0000000000000 == 0x0040000000000000 {
+			return 282
+		}
+		if w&0x00F0000000000000 == 0x0050000000000000 {
+			return 283
+		}
+		if w&0x00F0000000000000 == 0x0060000000000000 {
+			return 284
+		}
+	case 0x7A:
+		if w&0x00F8000000000000 == 0x0000000000000000 {
+			return 285
+		}
+		if w&0x00F8000000000000 == 0x0010000000000000 {
+			return 286
+		}
+		if w&0x00F8000000000000 == 0x0020000000000000 {
+			return 287
+		}
+		if w&0x00F8000000000000 == 0x0030000000000000 {
+			return 288
+		}
+		if w&0x00F8000000000000 == 0x0040000000000000 {
+			return 289
+		}
+		if w&0x00F8000000000000 == 0x0050000000000000 {
+			return 290
+		}
+		if w&0x00F8000000000000 == 0x0060000000000000 {
+			return 291
+		}
+	case 0x7B:
+		if w&0x00FFFFFF00000000 == 0x005C598F00000000 {
+			return 292
+		}
+		if w&0x00FFFFFF00000000 == 0x00D4598F00000000 {
+			return 293
+		}
+	case 0x7C:
+		if w&0x008FFF0000000000 == 0x0000630000000000 {
+			return 294
+		}
+		if w&0x008FFF8000000000 == 0x0000730000000000 {
+			return 295
+		}
+		if w&0x008FFF8000000000 == 0x0000740000000000 {
+			return 296
+		}
+		if w&0x008FFF8000000000 == 0x0000748000000000 {
+			return 297
+		}
+		if w&0x008FFF8000000000 == 0x0000750000000000 {
+			return 298
+		}
+		if w&0x008FFF8000000000 == 0x0000758000000000 {
+			return 299
+		}
+		if w&0x008FFF8000000000 == 0x0000760000000000 {
+			return 300
+		}
+		if w&0x008FFF8000000000 == 0x0000768000000000 {
+			return 301
+		}
+		if w&0x008FFF8000000000 == 0x0000770000000000 {
+			return 302
+		}
+		if w&0x008FFF8000000000 == 0x0000778000000000 {
+			return 303
+		}
+	case 0x7D:
+		if w&0x008FFF0000000000 == 0x0000600000000000 {
+			return 304
+		}
+		if w&0x008FFF0000000000 == 0x0000610000000000 {
+			return 305
+		}
+		if w&0x008FFF0000000000 == 0x0000620000000000 {
+			return 306
+		}
+		if w&0x008FFF8000000000 == 0x0000670000000000 {
+			return 307
+		}
+		if w&0x008FFF8000000000 == 0x0000678000000000 {
+			return 308
+		}
+		if w&0x008FFF8000000000 == 0x0000700000000000 {
+			return 309
+		}
+		if w&0x008FFF8000000000 == 0x0000710000000000 {
+			return 310
+		}
+		if w&0x008FFF8000000000 == 0x0000720000000000 {
+			return 311
+		}
+	case 0x7E:
+		if w&0x0000FF0F00000000 == 0x0000630000000000 {
+			return 312
+		}
+		if w&0x0000FF8000000000 == 0x0000730000000000 {
+			return 313
+		}
+		if w&0x0000FF8000000000 == 0x0000740000000000 {
+			return 314
+		}
+		if w&0x0000FF8000000000 == 0x0000748000000000 {
+			return 315
+		}
+		if w&0x0000FF8000000000 == 0x0000750000000000 {
+			return 316
+		}
+		if w&0x0000FF8000000000 == 0x0000758000000000 {
+			return 317
+		}
+		if w&0x0000FF8000000000 == 0x0000760000000000 {
+			return 318
+		}
+		if w&0x0000FF8000000000 == 0x0000768000000000 {
+			return 319
+		}
+		if w&0x0000FF8000000000 == 0x0000770000000000 {
+			return 320
+		}
+		if w&0x0000FF8000000000 == 0x0000778000000000 {
+			return 321
+		}
+	case 0x7F:
+		if w&0x0000FF0000000000 == 0x0000600000000000 {
+			return 322
+		}
+		if w&0x0000FF0000000000 == 0x0000610000000000 {
+			return 323
+		}
+		if w&0x0000FF0000000000 == 0x0000620000000000 {
+			return 324
+		}
+		if w&0x0000FF8000000000 == 0x0000670000000000 {
+			return 325
+		}
+		if w&0x0000FF8000000000 == 0x0000678000000000 {
+			return 326
+		}
+		if w&0x0000FF8000000000 == 0x0000700000000000 {
+			return 327
+		}
+		if w&0x0000FF8000000000 == 0x0000710000000000 {
+			return 328
+		}
+		if w&0x0000FF8000000000 == 0x0000720000000000 {
+			return 329
+		}
+	case 0x80:
+		return 330
+	case 0x81:
+		return 330
+	case 0x82:
+		return 330
+	case 0x83:
+		return 330
+	case 0x84:
+		return 330
+	case 0x85:
+		return 330
+	case 0x86:
+		return 330
+	case 0x87:
+		return 330
+	case 0x88:
+		return 330
+	case 0x89:
+		return 330
+	case 0x8A:
+		return 330
+	case 0x8B:
+		return 330
+	case 0x8C:
+		return 330
+	case 0x8D:
+		return 330
+	case 0x8E:
+		return 330
+	case 0x8F:
+		return 330
+	case 0x90:
+		return 331
+	case 0x91:
+		return 331
+	case 0x92:
+		return 331
+	case 0x93:
+		return 331
+	case 0x94:
+		return 331
+	case 0x95:
+		return 331
+	case 0x96:
+		return 331
+	case 0x97:
+		return 331
+	case 0x98:
+		return 331
+	case 0x99:
+		return 331
+	case 0x9A:
+		return 331
+	case 0x9B:
+		return 331
+	case 0x9C:
+		return 331
+	case 0x9D:
+		return 331
+	case 0x9E:
+		return 331
+	case 0x9F:
+		return 331
+	case 0xA0:
+		return 332
+	case 0xA1:
+		return 332
+	case 0xA2:
+		return 332
+	case 0xA3:
+		return 332
+	case 0xA4:
+		return 332
+	case 0xA5:
+		return 332
+	case 0xA6:
+		return 332
+	case 0xA7:
+		return 332
+	case 0xA8:
+		return 332
+	case 0xA9:
+		return 332
+	case 0xAA:
+		return 332
+	case 0xAB:
+		return 332
+	case 0xAC:
+		return 332
+	case 0xAD:
+		return 332
+	case 0xAE:
+		return 332
+	case 0xAF:
+		return 332
+	case 0xB0:
+		return 333
+	case 0xB1:
+		return 333
+	case 0xB2:
+		return 333
+	case 0xB3:
+		return 333
+	case 0xB4:
+		return 333
+	case 0xB5:
+		return 333
+	case 0xB6:
+		return 333
+	case 0xB7:
+		return 333
+	case 0xB8:
+		return 333
+	case 0xB9:
+		return 333
+	case 0xBA:
+		return 333
+	case 0xBB:
+		return 333
+	case 0xBC:
+		return 333
+	case 0xBD:
+		return 333
+	case 0xBE:
+		return 333
+	case 0xBF:
+		return 333
+	case 0xC0:
+		return 334
+	case 0xC1:
+		return 334
+	case 0xC2:
+		return 334
+	case 0xC3:
+		return 334
+	case 0xC4:
+		return 334
+	case 0xC5:
+		return 334
+	case 0xC6:
+		return 334
+	case 0xC7:
+		return 334
+	case 0xC8:
+		return 334
+	case 0xC9:
+		return 334
+	case 0xCA:
+		return 334
+	case 0xCB:
+		return 334
+	case 0xCC:
+		return 334
+	case 0xCD:
+		return 334
+	case 0xCE:
+		return 334
+	case 0xCF:
+		return 334
+	case 0xD0:
+		return 335
+	case 0xD1:
+		return 335
+	case 0xD2:
+		return 335
+	case 0xD3:
+		return 335
+	case 0xD4:
+		return 335
+	case 0xD5:
+		return 335
+	case 0xD6:
+		return 335
+	case 0xD7:
+		return 335
+	case 0xD8:
+		return 335
+	case 0xD9:
+		return 335
+	case 0xDA:
+		return 335
+	case 0xDB:
+		return 335
+	case 0xDC:
+		return 335
+	case 0xDD:
+		return 335
+	case 0xDE:
+		return 335
+	case 0xDF:
+		return 335
+	case 0xE0:
+		return 336
+	case 0xE1:
+		return 336
+	case 0xE2:
+		return 336
+	case 0xE3:
+		return 336
+	case 0xE4:
+		return 336
+	case 0xE5:
+		return 336
+	case 0xE6:
+		return 336
+	case 0xE7:
+		return 336
+	case 0xE8:
+		return 336
+	case 0xE9:
+		return 336
+	case 0xEA:
+		return 336
+	case 0xEB:
+		return 336
+	case 0xEC:
+		return 336
+	case 0xED:
+		return 336
+	case 0xEE:
+		return 336
+	case 0xEF:
+		return 336
+	case 0xF0:
+		return 337
+	case 0xF1:
+		return 337
+	case 0xF2:
+		return 337
+	case 0xF3:
+		return 337
+	case 0xF4:
+		return 337
+	case 0xF5:
+		return 337
+	case 0xF6:
+		return 337
+	case 0xF7:
+		return 337
+	case 0xF8:
+		return 337
+	case 0xF9:
+		return 337
+	case 0xFA:
+		return 337
+	case 0xFB:
+		return 337
+	case 0xFC:
+		return 337
+	case 0xFD:
+		return 337
+	case 0xFE:
+		return 337
+	case 0xFF:
+		return 337
+	}
+	return -1
+}
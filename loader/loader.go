@@ -0,0 +1,210 @@
+// contains code to recognise the images a Cybiko boots from, place their
+// contents at the bus addresses they occupy, and parse the h8s/2000 exception
+// vector table found at the bottom of the address space. The vector numbers
+// follow "H8S/2246 Group Hardware Manual", Section 4 (Exception Handling),
+// which the Cybiko Classic's CPU shares.
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Kind is the kind of image that was loaded.
+type Kind int
+
+const (
+	// Raw is an image that wasn't recognised. It is mapped at address 0, and
+	// has no vector table.
+	Raw Kind = iota
+	// BootROM is an image of the Cybiko's boot ROM, which occupies the bottom
+	// of the address space and starts with the exception vector table.
+	BootROM
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Raw:
+		return "raw"
+	case BootROM:
+		return "boot rom"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+const (
+	// VectorSize is the size of a vector table entry in advanced mode.
+	VectorSize = 4
+	// addressMask covers the 24 bit address space. The upper 8 bits of a
+	// vector are reserved.
+	addressMask = 0x00FFFFFF
+)
+
+// Vector is an exception vector number.
+type Vector int
+
+const (
+	Reset Vector = 0
+	Trace Vector = 5
+	NMI   Vector = 7
+	Trap0 Vector = 8
+	Trap1 Vector = 9
+	Trap2 Vector = 10
+	Trap3 Vector = 11
+	IRQ0  Vector = 16
+	IRQ1  Vector = 17
+	IRQ2  Vector = 18
+	IRQ3  Vector = 19
+	IRQ4  Vector = 20
+	IRQ5  Vector = 21
+	IRQ6  Vector = 22
+	IRQ7  Vector = 23
+)
+
+// Vectors lists every vector with a name, in the order they appear in the
+// table.
+var Vectors = []Vector{
+	Reset, Trace, NMI, Trap0, Trap1, Trap2, Trap3,
+	IRQ0, IRQ1, IRQ2, IRQ3, IRQ4, IRQ5, IRQ6, IRQ7,
+}
+
+func (v Vector) String() string {
+	switch {
+	case v == Reset:
+		return "reset"
+	case v == Trace:
+		return "trace"
+	case v == NMI:
+		return "nmi"
+	case v >= Trap0 && v <= Trap3:
+		return fmt.Sprintf("trapa #%d", int(v-Trap0))
+	case v >= IRQ0 && v <= IRQ7:
+		return fmt.Sprintf("irq%d", int(v-IRQ0))
+	}
+	return fmt.Sprintf("vector %d", int(v))
+}
+
+// Address returns the address of the vector table entry for v.
+func (v Vector) Address() uint32 {
+	return uint32(v) * VectorSize
+}
+
+// Segment is a contiguous run of bytes placed at a bus address.
+type Segment struct {
+	Addr uint32
+	Data []byte
+}
+
+// End returns the address just past the last byte of the segment.
+func (s Segment) End() uint32 {
+	return s.Addr + uint32(len(s.Data))
+}
+
+// Image is a loaded image.
+type Image struct {
+	Kind     Kind
+	Segments []Segment
+	// Vectors holds the address each named vector points to. It is empty if
+	// the image doesn't contain the vector table.
+	Vectors map[Vector]uint32
+}
+
+// ErrFlashBoot is returned by LoadFlashBoot, as flash boot images can't be
+// loaded yet.
+var ErrFlashBoot = errors.New("flash boot images can't be loaded yet, as where their code is placed on the bus isn't known")
+
+// Load recognises the kind of image in data, and maps it onto the bus. Images
+// that aren't recognised are loaded as Raw. Load can't tell a flash boot image
+// from any other data, so those are loaded as Raw too; use LoadFlashBoot for
+// them instead.
+func Load(data []byte) (*Image, error) {
+	im := &Image{
+		Kind:     Raw,
+		Segments: []Segment{{Addr: 0, Data: data}},
+		Vectors:  map[Vector]uint32{},
+	}
+	if !hasVectorTable(data) {
+		return im, nil
+	}
+	im.Kind = BootROM
+	for _, v := range Vectors {
+		if addr, ok := im.Read32(v.Address()); ok {
+			im.Vectors[v] = addr & addressMask
+		}
+	}
+	return im, nil
+}
+
+// LoadFlashBoot maps a flash boot image, a dump of the DataFlash the boot ROM
+// loads CyOS from, onto the bus. Which pages of it are copied where isn't
+// known yet, so it always fails with ErrFlashBoot rather than guessing.
+func LoadFlashBoot(data []byte) (*Image, error) {
+	return nil, ErrFlashBoot
+}
+
+// hasVectorTable returns true if data plausibly starts with a vector table:
+// every named vector must point to an even address inside data, past the end
+// of the named vectors. Code rarely passes this by chance, as it would need
+// fifteen such longwords at the right places.
+func hasVectorTable(data []byte) bool {
+	if len(data) < int(IRQ7.Address())+VectorSize {
+		return false
+	}
+	for _, v := range Vectors {
+		a := v.Address()
+		addr := uint32(data[a])<<24 | uint32(data[a+1])<<16 | uint32(data[a+2])<<8 | uint32(data[a+3])
+		addr &= addressMask
+		if addr&1 != 0 || addr < IRQ7.Address()+VectorSize || addr >= uint32(len(data)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Read returns the n bytes at addr. It returns false if any of them aren't
+// mapped.
+func (im *Image) Read(addr uint32, n int) ([]byte, bool) {
+	for _, s := range im.Segments {
+		if addr >= s.Addr && uint64(addr)+uint64(n) <= uint64(s.End()) {
+			off := addr - s.Addr
+			return s.Data[off : off+uint32(n)], true
+		}
+	}
+	return nil, false
+}
+
+// Read32 returns the big endian longword at addr.
+func (im *Image) Read32(addr uint32) (uint32, bool) {
+	b, ok := im.Read(addr, 4)
+	if !ok {
+		return 0, false
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), true
+}
+
+// EntryPoint returns the address execution starts at after a reset.
+func (im *Image) EntryPoint() (uint32, bool) {
+	addr, ok := im.Vectors[Reset]
+	return addr, ok
+}
+
+// EntryPoints returns, in ascending order and without duplicates, every
+// address a named vector points to that is mapped in the image. Vectors
+// pointing into the vector table itself are left unused by most code, and are
+// skipped.
+func (im *Image) EntryPoints() []uint32 {
+	seen := map[uint32]bool{}
+	entries := []uint32{}
+	for _, addr := range im.Vectors {
+		if seen[addr] || addr < IRQ7.Address()+VectorSize {
+			continue
+		}
+		seen[addr] = true
+		if _, ok := im.Read(addr, 2); ok {
+			entries = append(entries, addr)
+		}
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a] < entries[b] })
+	return entries
+}
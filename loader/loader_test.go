@@ -0,0 +1,100 @@
+package loader_test
+
+import (
+	"testing"
+
+	"github.com/kn100/cybemu/dataflash"
+	"github.com/kn100/cybemu/loader"
+	"github.com/stretchr/testify/assert"
+)
+
+// bootROM returns a small image with a vector table whose reset vector points
+// to 0x100, NMI to 0x120, TRAPA #2 to 0x140 and the others to 0x180.
+func bootROM() []byte {
+	rom := make([]byte, 0x200)
+	put := func(v loader.Vector, addr uint32) {
+		a := v.Address()
+		rom[a], rom[a+1], rom[a+2], rom[a+3] = byte(addr>>24), byte(addr>>16), byte(addr>>8), byte(addr)
+	}
+	for _, v := range loader.Vectors {
+		put(v, 0x180)
+	}
+	put(loader.Reset, 0x100)
+	put(loader.NMI, 0x120)
+	put(loader.Trap2, 0xFF000140) // the reserved upper byte is ignored
+	return rom
+}
+
+func TestLoadBootROM(t *testing.T) {
+	im, err := loader.Load(bootROM())
+	assert.NoError(t, err)
+	assert.Equal(t, loader.BootROM, im.Kind)
+
+	entry, ok := im.EntryPoint()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0x100), entry)
+	assert.Equal(t, uint32(0x120), im.Vectors[loader.NMI])
+	assert.Equal(t, uint32(0x140), im.Vectors[loader.Trap2])
+	assert.Equal(t, uint32(0x180), im.Vectors[loader.IRQ7])
+	assert.Equal(t, []uint32{0x100, 0x120, 0x140, 0x180}, im.EntryPoints())
+}
+
+func TestLoadRaw(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{name: "too short", data: []byte{0x00, 0x00, 0x00, 0x10}},
+		{name: "odd reset vector", data: append([]byte{0x00, 0x00, 0x01, 0x01}, make([]byte, 0x200)...)},
+		{name: "reset vector outside image", data: append([]byte{0x00, 0x01, 0x00, 0x00}, make([]byte, 0x200)...)},
+		{name: "reset vector inside table", data: append([]byte{0x00, 0x00, 0x00, 0x10}, make([]byte, 0x200)...)},
+		{name: "only the reset vector is plausible", data: append([]byte{0x00, 0x00, 0x01, 0x00}, make([]byte, 0x200)...)},
+		{name: "odd irq vector", data: oddVector()},
+		{name: "dataflash dump", data: make([]byte, dataflash.Size)},
+	}
+	for _, tc := range testCases {
+		im, err := loader.Load(tc.data)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, loader.Raw, im.Kind, tc.name)
+		assert.Empty(t, im.Vectors, tc.name)
+		_, ok := im.EntryPoint()
+		assert.False(t, ok, tc.name)
+		assert.Equal(t, []loader.Segment{{Addr: 0, Data: tc.data}}, im.Segments, tc.name)
+	}
+}
+
+func TestLoadFlashBoot(t *testing.T) {
+	_, err := loader.LoadFlashBoot(make([]byte, dataflash.Size))
+	assert.ErrorIs(t, err, loader.ErrFlashBoot)
+}
+
+// oddVector returns bootROM with irq3 pointing to an odd address.
+func oddVector() []byte {
+	rom := bootROM()
+	rom[loader.IRQ3.Address()+3] = 0x81
+	return rom
+}
+
+func TestRead(t *testing.T) {
+	im := &loader.Image{Segments: []loader.Segment{
+		{Addr: 0x1000, Data: []byte{0x01, 0x02, 0x03, 0x04}},
+		{Addr: 0x2000, Data: []byte{0x05, 0x06}},
+	}}
+	b, ok := im.Read(0x1002, 2)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x03, 0x04}, b)
+	_, ok = im.Read(0x1002, 3)
+	assert.False(t, ok, "reads must not run off the end of a segment")
+	_, ok = im.Read(0x1FFF, 1)
+	assert.False(t, ok)
+	l, ok := im.Read32(0x1000)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0x01020304), l)
+}
+
+func TestVectorString(t *testing.T) {
+	assert.Equal(t, "reset", loader.Reset.String())
+	assert.Equal(t, "trapa #3", loader.Trap3.String())
+	assert.Equal(t, "irq5", loader.IRQ5.String())
+	assert.Equal(t, "vector 30", loader.Vector(30).String())
+}
@@ -0,0 +1,26 @@
+// contains what an emulated machine exposes to the tools which drive it, such
+// as the debugger stub, the monitor and the runner: its register file, its
+// memory, and a way to execute one instruction at a time.
+package machine
+
+// Registers is the register file of the h8s/2000 CPU.
+type Registers struct {
+	// ER holds the general registers ER0 to ER7. ER7 is the stack pointer.
+	ER  [8]uint32
+	PC  uint32
+	CCR byte
+	EXR byte
+}
+
+// Target is a machine which can be inspected and stepped.
+type Target interface {
+	Registers() Registers
+	SetRegisters(Registers)
+	// ReadMemory fills buf with the bytes found at addr.
+	ReadMemory(addr uint32, buf []byte) error
+	// WriteMemory stores data at addr.
+	WriteMemory(addr uint32, data []byte) error
+	// Step executes a single instruction. An error means the target couldn't
+	// execute the instruction at PC.
+	Step() error
+}
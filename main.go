@@ -1,35 +1,389 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/kn100/cybemu/asmprinter"
+	"github.com/kn100/cybemu/batch"
 	"github.com/kn100/cybemu/disassembler"
+	"github.com/kn100/cybemu/instruction"
+	"github.com/kn100/cybemu/loader"
+	"github.com/kn100/cybemu/monitor"
+	"github.com/kn100/cybemu/regions"
+	"github.com/kn100/cybemu/resolve"
+	"github.com/kn100/cybemu/snapshot"
+	"github.com/kn100/cybemu/xref"
 )
 
+const usage = `Usage: cybemu [--flash] [--report-invalid] [--strict] <file>
+       cybemu [--flash] xref <file> <addr>
+       cybemu [--flash] debug <file>
+       cybemu [--flash] snapshot save <file> <snapshot>
+       cybemu [--flash] snapshot load <file> <snapshot>
+       cybemu [--flash] run --until <condition> [run options] <file>
+
+--flash loads the file as a flash boot image rather than recognising what it
+is.
+--report-invalid lists every invalid encoding found after disassembling.
+--strict lists every instruction which can't be written out, and exits with
+an error if there are any.
+
+debug runs the file under an interactive monitor; type help for its commands.
+snapshot save boots the file and saves the state it starts in, and snapshot
+load runs the monitor from a saved state instead of from a cold boot.
+
+run runs the file without any UI until a condition is met, exiting with 0 if
+one was, 1 if the machine stopped with an error, or 2 if it ran out of
+cycles. A condition is pc=<addr>, cycles=<n>, trapa=<n> or serial=<text>.
+Its options are:
+--until <condition>  stop once condition is met; may be given more than once
+--max-cycles <n>     give up after n cycles
+--snapshot <file>    start from the snapshot in file rather than a cold boot
+--registers          print the registers once the run ends
+--screenshot <file>  save what the display shows once the run ends as a PNG
+--serial <file>      save everything sent out of the serial port`
+
+// errNoEngine is returned by newMachine, as nothing can execute code yet.
+var errNoEngine = errors.New("there's no execution engine yet, so code can't be run")
+
 func main() {
-	if len(os.Args) != 2 {
-		fmt.Println("Usage: disassembler <file>")
+	args := os.Args[1:]
+	flash, reportInvalid, strict := false, false, false
+	for len(args) > 0 {
+		if args[0] == "--flash" {
+			flash = true
+		} else if args[0] == "--report-invalid" {
+			reportInvalid = true
+		} else if args[0] == "--strict" {
+			strict = true
+		} else {
+			break
+		}
+		args = args[1:]
+	}
+	switch {
+	case len(args) == 1:
+		if !disassemble(args[0], flash, reportInvalid, strict) {
+			os.Exit(1)
+		}
+	case len(args) == 3 && args[0] == "xref" && !reportInvalid && !strict:
+		if !crossReference(args[1], args[2], flash) {
+			os.Exit(1)
+		}
+	case len(args) == 2 && args[0] == "debug" && !reportInvalid && !strict:
+		if !debug(args[1], flash, "") {
+			os.Exit(1)
+		}
+	case len(args) == 4 && args[0] == "snapshot" && args[1] == "save" && !reportInvalid && !strict:
+		if !saveSnapshot(args[2], flash, args[3]) {
+			os.Exit(1)
+		}
+	case len(args) == 4 && args[0] == "snapshot" && args[1] == "load" && !reportInvalid && !strict:
+		if !debug(args[2], flash, args[3]) {
+			os.Exit(1)
+		}
+	case len(args) > 1 && args[0] == "run" && !reportInvalid && !strict:
+		os.Exit(run(args[1:], flash))
+	default:
+		fmt.Println(usage)
 		os.Exit(1)
 	}
+}
 
-	args := os.Args[1:]
-	f, err := os.Open(args[0])
+// load loads the image in the file at path, as a flash boot image if flash is
+// set, printing why if it can't.
+func load(path string, flash bool) (*loader.Image, bool) {
+	bytes, err := os.ReadFile(path)
 	if err != nil {
 		fmt.Printf("Couldn't open file for some reason. Error was: %s\n", err)
-		return
+		return nil, false
+	}
+	load := loader.Load
+	if flash {
+		load = loader.LoadFlashBoot
+	}
+	image, err := load(bytes)
+	if err != nil {
+		fmt.Printf("Couldn't load file for some reason. Error was: %s\n", err)
+		return nil, false
+	}
+	return image, true
+}
+
+// code disassembles each code region of m, returning the instructions of the
+// nth region of m at n. Data regions have none.
+func code(m regions.Map) [][]instruction.Inst {
+	code := make([][]instruction.Inst, len(m))
+	for n, r := range m {
+		if r.Kind == regions.Code {
+			code[n] = disassembler.DisassembleAt(r.Data, int(r.Addr))
+		}
+	}
+	return code
+}
+
+// disassemble prints the image in the file at path, loaded as a flash boot
+// image if flash is set, and if reportInvalid is
+// set, a report of the invalid instructions in it to stderr. If strict is set,
+// it also prints to stderr every instruction which can't be written out. It
+// returns false if the file can't be loaded, or if strict is set and there
+// were any.
+func disassemble(path string, flash, reportInvalid, strict bool) bool {
+	image, ok := load(path, flash)
+	if !ok {
+		return false
+	}
+	for _, v := range loader.Vectors {
+		if addr, ok := image.Vectors[v]; ok {
+			fmt.Printf("; %-10s 0x%06X\n", v, addr)
+		}
+	}
+	m := regions.Classify(image)
+	insts := code(m)
+	var all []instruction.Inst
+	for n, r := range m {
+		if r.Kind == regions.Code {
+			asmprinter.PrintAssy(insts[n])
+			all = append(all, insts[n]...)
+		} else {
+			asmprinter.PrintRegion(r, m.Name)
+		}
+	}
+	if reportInvalid {
+		asmprinter.FprintInvalid(os.Stderr, all)
+	}
+	if !strict {
+		return true
+	}
+	ok = true
+	for n := range all {
+		if _, err := all[n].Format(); err != nil {
+			fmt.Fprintf(os.Stderr, "%05x: %s\n", all[n].Pos, err)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// crossReference prints every instruction in the image in the file at path
+// which refers to addr, loading it as a flash boot image if flash is set. It
+// returns false if addr or the file can't be read.
+func crossReference(path, addr string, flash bool) bool {
+	target, err := strconv.ParseUint(addr, 0, 32)
+	if err != nil {
+		fmt.Printf("Couldn't understand address %q\n", addr)
+		return false
+	}
+	image, ok := load(path, flash)
+	if !ok {
+		return false
+	}
+	ix := xref.New()
+	insts := map[uint32]instruction.Inst{}
+	for _, instructions := range code(regions.Classify(image)) {
+		for n := range instructions {
+			ix.AddInst(&instructions[n])
+			insts[uint32(instructions[n].Pos)] = instructions[n]
+		}
+		for _, r := range resolve.Resolve(instructions, image) {
+			ix.Add(r)
+		}
+	}
+	for _, r := range ix.To(uint32(target)) {
+		fmt.Printf("%-5s %s\n", r.Kind, asmprinter.FormatInst(insts[r.From]))
+	}
+	return true
+}
+
+// emulator is what the commands which run code need of a machine.
+type emulator interface {
+	batch.Machine
+	snapshot.Machine
+}
+
+// newMachine returns a machine which boots image.
+func newMachine(image *loader.Image) (emulator, error) {
+	return nil, errNoEngine
+}
+
+// boot returns a machine running the image in the file at path, loaded as a
+// flash boot image if flash is set, printing why if it can't. If from isn't
+// empty, the machine is restored to the snapshot in that file.
+func boot(path string, flash bool, from string) (emulator, bool) {
+	var saved *snapshot.Snapshot
+	if from != "" {
+		var err error
+		if saved, err = snapshot.LoadFile(from); err != nil {
+			fmt.Printf("Couldn't load snapshot. Error was: %s\n", err)
+			return nil, false
+		}
+	}
+	image, ok := load(path, flash)
+	if !ok {
+		return nil, false
 	}
-	defer f.Close()
+	target, err := newMachine(image)
+	if err != nil {
+		fmt.Printf("Couldn't start the machine. Error was: %s\n", err)
+		return nil, false
+	}
+	if saved != nil {
+		if err := target.Restore(saved); err != nil {
+			fmt.Printf("Couldn't restore snapshot. Error was: %s\n", err)
+			return nil, false
+		}
+	}
+	return target, true
+}
 
-	finfo, err := os.Stat(args[0])
+// debug runs the monitor on the image in the file at path, as boot starts it,
+// reading commands from stdin. It returns false if the image can't be run.
+func debug(path string, flash bool, from string) bool {
+	target, ok := boot(path, flash, from)
+	if !ok {
+		return false
+	}
+	if err := monitor.New(target, os.Stdout).Run(os.Stdin); err != nil {
+		fmt.Printf("Couldn't read commands. Error was: %s\n", err)
+		return false
+	}
+	return true
+}
+
+// saveSnapshot boots the image in the file at path, loaded as a flash boot
+// image if flash is set, and saves the state it starts in to the file at to.
+// It returns false if it can't.
+func saveSnapshot(path string, flash bool, to string) bool {
+	target, ok := boot(path, flash, "")
+	if !ok {
+		return false
+	}
+	s, err := target.Snapshot()
+	if err == nil {
+		err = snapshot.SaveFile(to, s)
+	}
 	if err != nil {
-		fmt.Printf("Couldn't get info about file for some reason. Error was: %s\n", err)
-		return
+		fmt.Printf("Couldn't save snapshot. Error was: %s\n", err)
+		return false
 	}
+	return true
+}
 
-	bytes := make([]byte, finfo.Size())
-	f.Read(bytes)
-	instructions := disassembler.Disassemble(bytes)
-	asmprinter.PrintAssy(instructions)
+// runOptions are the options given to cybemu run.
+type runOptions struct {
+	until      []batch.Condition
+	maxCycles  uint64
+	from       string
+	registers  bool
+	screenshot string
+	serial     string
+}
+
+// parseRun parses the arguments given to cybemu run, returning its options and
+// the file to run.
+func parseRun(args []string) (runOptions, string, error) {
+	var opts runOptions
+	for len(args) > 1 {
+		option := args[0]
+		if option == "--registers" {
+			opts.registers = true
+			args = args[1:]
+			continue
+		}
+		if len(args) < 3 {
+			return opts, "", fmt.Errorf("%s needs a value and a file to run", option)
+		}
+		value := args[1]
+		switch option {
+		case "--until":
+			c, err := batch.ParseCondition(value)
+			if err != nil {
+				return opts, "", err
+			}
+			opts.until = append(opts.until, c)
+		case "--max-cycles":
+			n, err := strconv.ParseUint(value, 0, 64)
+			if err != nil {
+				return opts, "", fmt.Errorf("bad number of cycles %q", value)
+			}
+			opts.maxCycles = n
+		case "--snapshot":
+			opts.from = value
+		case "--screenshot":
+			opts.screenshot = value
+		case "--serial":
+			opts.serial = value
+		default:
+			return opts, "", fmt.Errorf("unknown option %q", option)
+		}
+		args = args[2:]
+	}
+	if len(args) != 1 {
+		return opts, "", errors.New("run needs a file to run")
+	}
+	if len(opts.until) == 0 {
+		return opts, "", errors.New("run needs at least one --until condition")
+	}
+	return opts, args[0], nil
+}
+
+// run runs an image headless as the arguments given to cybemu run say,
+// loading it as a flash boot image if flash is set. It returns the status to
+// exit with.
+func run(args []string, flash bool) int {
+	opts, path, err := parseRun(args)
+	if err != nil {
+		fmt.Println(err)
+		fmt.Println(usage)
+		return batch.ExitError
+	}
+	target, ok := boot(path, flash, opts.from)
+	if !ok {
+		return batch.ExitError
+	}
+	result := batch.Run(target, opts.until, opts.maxCycles)
+	switch result.Status {
+	case batch.ExitMet:
+		fmt.Printf("Met %s after %d cycles\n", result.Met, target.Cycles())
+	case batch.ExitError:
+		fmt.Printf("Stopped at 0x%06X after %d cycles. Error was: %s\n", target.Registers().PC, target.Cycles(), result.Err)
+	case batch.ExitTimeout:
+		fmt.Printf("Gave up after %d cycles\n", target.Cycles())
+	}
+	if !dump(target, opts) {
+		return batch.ExitError
+	}
+	return result.Status
+}
+
+// dump writes out whatever opts asks for once a run has ended. It returns
+// false if any of it can't be written.
+func dump(target emulator, opts runOptions) bool {
+	ok := true
+	if opts.registers {
+		batch.WriteRegisters(os.Stdout, target.Registers())
+	}
+	if opts.serial != "" {
+		if err := os.WriteFile(opts.serial, target.SerialOutput(), 0o644); err != nil {
+			fmt.Printf("Couldn't save serial output. Error was: %s\n", err)
+			ok = false
+		}
+	}
+	if opts.screenshot != "" {
+		f, err := os.Create(opts.screenshot)
+		if err == nil {
+			err = batch.WriteScreenshot(f, target)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+		if err != nil {
+			fmt.Printf("Couldn't save screenshot. Error was: %s\n", err)
+			ok = false
+		}
+	}
+	return ok
 }
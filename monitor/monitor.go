@@ -0,0 +1,450 @@
+// contains a small interactive monitor for stepping through code running in
+// the emulator, for those who'd rather not reach for GDB. It drives the same
+// machine.Target the GDB stub does, and reads one command per line.
+package monitor
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kn100/cybemu/asmprinter"
+	"github.com/kn100/cybemu/disassembler"
+	"github.com/kn100/cybemu/instruction"
+	"github.com/kn100/cybemu/machine"
+	"github.com/kn100/cybemu/rewind"
+)
+
+// Prompt is printed before reading each command.
+const Prompt = "(cybemu) "
+
+var errQuit = errors.New("quit")
+
+// Reverser is implemented by targets which can run backwards, such as
+// rewind.Rewinder.
+type Reverser interface {
+	StepBack(n int) error
+	ReverseToWrite(watches ...rewind.Watch) error
+}
+
+// watchpoint stops execution when any of the bytes it covers change.
+type watchpoint struct {
+	addr   uint32
+	length int
+	last   []byte
+}
+
+// Monitor is a command interpreter for a single Target.
+type Monitor struct {
+	target      machine.Target
+	out         io.Writer
+	breakpoints map[uint32]bool
+	watchpoints []*watchpoint
+}
+
+// New returns a Monitor for target, which writes its output to out.
+func New(target machine.Target, out io.Writer) *Monitor {
+	return &Monitor{
+		target:      target,
+		out:         out,
+		breakpoints: map[uint32]bool{},
+	}
+}
+
+// Run reads commands from in until it is exhausted or the quit command is
+// given. Errors from individual commands are printed, and don't stop Run.
+func (m *Monitor) Run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(m.out, Prompt)
+		if !scanner.Scan() {
+			fmt.Fprintln(m.out)
+			return scanner.Err()
+		}
+		err := m.Exec(scanner.Text())
+		if err == errQuit {
+			return nil
+		} else if err != nil {
+			fmt.Fprintf(m.out, "error: %s\n", err)
+		}
+	}
+}
+
+// Exec runs a single command.
+func (m *Monitor) Exec(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	args := fields[1:]
+	switch fields[0] {
+	case "help", "h", "?":
+		m.help()
+	case "quit", "q":
+		return errQuit
+	case "regs", "r":
+		m.printRegisters()
+	case "set":
+		if len(args) != 2 {
+			return errors.New("usage: set <register> <value>")
+		}
+		return m.setRegister(args[0], args[1])
+	case "step", "s":
+		count, err := m.optionalCount(args, 1)
+		if err != nil {
+			return err
+		}
+		return m.run(count, nil)
+	case "next", "n":
+		return m.next()
+	case "continue", "c":
+		limit, err := m.optionalCount(args, 0)
+		if err != nil {
+			return err
+		}
+		return m.run(limit, nil)
+	case "rstep", "rs":
+		count, err := m.optionalCount(args, 1)
+		if err != nil {
+			return err
+		}
+		return m.reverse(func(r Reverser) error { return r.StepBack(count) })
+	case "rcontinue", "rc":
+		if len(m.watchpoints) == 0 {
+			return errors.New("rcontinue needs a watchpoint to run back to")
+		}
+		watches := make([]rewind.Watch, len(m.watchpoints))
+		for n, w := range m.watchpoints {
+			watches[n] = rewind.Watch{Addr: w.addr, Length: w.length}
+		}
+		return m.reverse(func(r Reverser) error { return r.ReverseToWrite(watches...) })
+	case "break", "b":
+		if len(args) == 0 {
+			m.printBreakpoints()
+			return nil
+		}
+		addr, err := m.value(args[0])
+		if err != nil {
+			return err
+		}
+		m.breakpoints[addr] = true
+	case "delete", "d":
+		if len(args) != 1 {
+			return errors.New("usage: delete <address>")
+		}
+		addr, err := m.value(args[0])
+		if err != nil {
+			return err
+		}
+		if !m.breakpoints[addr] {
+			return fmt.Errorf("no breakpoint at 0x%06X", addr)
+		}
+		delete(m.breakpoints, addr)
+	case "watch", "w":
+		return m.watch(args)
+	case "unwatch":
+		if len(args) != 1 {
+			return errors.New("usage: unwatch <address>")
+		}
+		return m.unwatch(args[0])
+	case "x":
+		return m.dumpMemory(args)
+	case "dis":
+		return m.disassemble(args)
+	default:
+		return fmt.Errorf("unknown command %q, try help", fields[0])
+	}
+	return nil
+}
+
+func (m *Monitor) help() {
+	fmt.Fprint(m.out, `regs                     show the registers
+set <reg> <value>        set a register (er0-er7, sp, pc, ccr, exr)
+step [count]             execute count instructions (default 1)
+next                     step, running over bsr and jsr calls
+continue [limit]         run until a breakpoint or watchpoint is hit
+rstep [count]            go back count instructions (default 1)
+rcontinue                go back to the last write to a watchpoint
+break [addr]             set a breakpoint, or list them
+delete <addr>            delete a breakpoint
+watch [addr] [length]    stop when memory changes, or list watchpoints
+unwatch <addr>           delete a watchpoint
+x <addr> [length]        dump memory
+dis [addr] [count]       disassemble, around pc by default
+quit                     leave the monitor
+Numbers may be given in decimal, or in hex with 0x. Register names may be
+used in place of a number.
+`)
+}
+
+// optionalCount parses an optional count argument, returning def if there
+// isn't one.
+func (m *Monitor) optionalCount(args []string, def int) (int, error) {
+	if len(args) == 0 {
+		return def, nil
+	}
+	n, err := strconv.ParseUint(args[0], 0, 31)
+	if err != nil {
+		return 0, fmt.Errorf("bad count %q", args[0])
+	}
+	return int(n), nil
+}
+
+// value parses a number, or returns the value of the register it names.
+func (m *Monitor) value(s string) (uint32, error) {
+	regs := m.target.Registers()
+	if r := registerByName(&regs, s); r != nil {
+		return r.get(), nil
+	}
+	n, err := strconv.ParseUint(s, 0, 32)
+	if err != nil {
+		return 0, fmt.Errorf("bad number %q", s)
+	}
+	return uint32(n), nil
+}
+
+// run steps the target until a breakpoint or watchpoint is hit, the target
+// fails to step, until returns true, or limit instructions have been executed.
+// A limit of 0 means there is no limit. Breakpoints are only checked after the
+// first instruction, so that running from a breakpoint doesn't stop on it.
+func (m *Monitor) run(limit int, until func() bool) error {
+	for _, w := range m.watchpoints {
+		w.last = m.readWatched(w)
+	}
+	for n := 1; ; n++ {
+		if err := m.target.Step(); err != nil {
+			m.printCurrent()
+			return fmt.Errorf("target stopped: %w", err)
+		}
+		pc := m.target.Registers().PC
+		if m.breakpoints[pc] {
+			fmt.Fprintf(m.out, "breakpoint at 0x%06X\n", pc)
+			break
+		}
+		if w := m.changedWatchpoint(); w != nil {
+			fmt.Fprintf(m.out, "watchpoint at 0x%06X changed to % x\n", w.addr, w.last)
+			break
+		}
+		if until != nil && until() {
+			break
+		}
+		if n == limit {
+			break
+		}
+	}
+	m.printCurrent()
+	return nil
+}
+
+// reverse runs the target backwards with do, if it can.
+func (m *Monitor) reverse(do func(Reverser) error) error {
+	r, ok := m.target.(Reverser)
+	if !ok {
+		return errors.New("this target can't run backwards")
+	}
+	err := do(r)
+	if err == rewind.ErrNoWrite {
+		fmt.Fprintln(m.out, "no earlier write to a watchpoint")
+		err = nil
+	}
+	m.printCurrent()
+	return err
+}
+
+// next steps a single instruction, unless it is a bsr or jsr, in which case
+// it runs until the call returns.
+func (m *Monitor) next() error {
+	regs := m.target.Registers()
+	inst, err := disassembler.DecodeAt(m.target, regs.PC)
+	if err != nil {
+		return err
+	}
+	if inst.Class()&instruction.Call == 0 {
+		return m.run(1, nil)
+	}
+	ret := regs.PC + uint32(inst.TotalBytes)
+	sp := regs.ER[7]
+	return m.run(0, func() bool {
+		regs := m.target.Registers()
+		// A recursive call can reach ret with more on the stack.
+		return regs.PC == ret && regs.ER[7] >= sp
+	})
+}
+
+func (m *Monitor) printBreakpoints() {
+	addrs := make([]uint32, 0, len(m.breakpoints))
+	for addr := range m.breakpoints {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(a, b int) bool { return addrs[a] < addrs[b] })
+	for _, addr := range addrs {
+		fmt.Fprintf(m.out, "breakpoint 0x%06X\n", addr)
+	}
+}
+
+func (m *Monitor) watch(args []string) error {
+	if len(args) == 0 {
+		for _, w := range m.watchpoints {
+			fmt.Fprintf(m.out, "watchpoint 0x%06X, %d bytes\n", w.addr, w.length)
+		}
+		return nil
+	}
+	addr, err := m.value(args[0])
+	if err != nil {
+		return err
+	}
+	length, err := m.optionalCount(args[1:], 1)
+	if err != nil {
+		return err
+	}
+	if length == 0 {
+		return errors.New("a watchpoint must cover at least one byte")
+	}
+	w := &watchpoint{addr: addr, length: length}
+	if err := m.target.ReadMemory(addr, make([]byte, length)); err != nil {
+		return err
+	}
+	m.watchpoints = append(m.watchpoints, w)
+	return nil
+}
+
+func (m *Monitor) unwatch(arg string) error {
+	addr, err := m.value(arg)
+	if err != nil {
+		return err
+	}
+	for n, w := range m.watchpoints {
+		if w.addr == addr {
+			m.watchpoints = append(m.watchpoints[:n], m.watchpoints[n+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no watchpoint at 0x%06X", addr)
+}
+
+func (m *Monitor) readWatched(w *watchpoint) []byte {
+	buf := make([]byte, w.length)
+	// Watched memory was readable when the watchpoint was set. If it no longer
+	// is, it reads as zeroes.
+	m.target.ReadMemory(w.addr, buf)
+	return buf
+}
+
+// changedWatchpoint returns the first watchpoint whose memory has changed
+// since it was last looked at.
+func (m *Monitor) changedWatchpoint() *watchpoint {
+	for _, w := range m.watchpoints {
+		now := m.readWatched(w)
+		if string(now) != string(w.last) {
+			w.last = now
+			return w
+		}
+	}
+	return nil
+}
+
+func (m *Monitor) dumpMemory(args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errors.New("usage: x <address> [length]")
+	}
+	addr, err := m.value(args[0])
+	if err != nil {
+		return err
+	}
+	length, err := m.optionalCount(args[1:], 64)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, length)
+	if err := m.target.ReadMemory(addr, buf); err != nil {
+		return err
+	}
+	for off := 0; off < len(buf); off += 16 {
+		line := buf[off:]
+		if len(line) > 16 {
+			line = line[:16]
+		}
+		ascii := make([]byte, len(line))
+		for i, b := range line {
+			ascii[i] = '.'
+			if b >= 0x20 && b < 0x7F {
+				ascii[i] = b
+			}
+		}
+		fmt.Fprintf(m.out, "%06x: %-47s  |%s|\n", int(addr)+off, fmt.Sprintf("% x", line), ascii)
+	}
+	return nil
+}
+
+// disassemble prints count instructions starting at an address, or around PC
+// if no address is given.
+func (m *Monitor) disassemble(args []string) error {
+	pc := m.target.Registers().PC
+	addr := m.syncStart(pc)
+	if len(args) > 0 {
+		var err error
+		if addr, err = m.value(args[0]); err != nil {
+			return err
+		}
+		args = args[1:]
+	}
+	count, err := m.optionalCount(args, 10)
+	if err != nil {
+		return err
+	}
+	for n := 0; n < count; n++ {
+		inst, err := disassembler.DecodeAt(m.target, addr)
+		if err != nil {
+			return err
+		}
+		m.printInst(inst, pc)
+		addr += uint32(inst.TotalBytes)
+	}
+	return nil
+}
+
+// syncStart picks an address a few instructions before pc, from which
+// decoding lands on pc. Instructions are variable length, so this can't
+// simply step back a fixed distance.
+func (m *Monitor) syncStart(pc uint32) uint32 {
+	for back := uint32(8); back > 0; back -= 2 {
+		if back > pc {
+			continue
+		}
+		addr := pc - back
+		for addr < pc {
+			inst, err := disassembler.DecodeAt(m.target, addr)
+			if err != nil {
+				break
+			}
+			addr += uint32(inst.TotalBytes)
+		}
+		if addr == pc {
+			return pc - back
+		}
+	}
+	return pc
+}
+
+func (m *Monitor) printInst(inst instruction.Inst, pc uint32) {
+	marker := "  "
+	if uint32(inst.Pos) == pc {
+		marker = "=>"
+	}
+	fmt.Fprintf(m.out, "%s %s\n", marker, asmprinter.FormatInst(inst))
+}
+
+// printCurrent prints the instruction about to be executed.
+func (m *Monitor) printCurrent() {
+	pc := m.target.Registers().PC
+	inst, err := disassembler.DecodeAt(m.target, pc)
+	if err != nil {
+		fmt.Fprintf(m.out, "pc 0x%06X isn't readable: %s\n", pc, err)
+		return
+	}
+	m.printInst(inst, pc)
+}
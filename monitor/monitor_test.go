@@ -0,0 +1,130 @@
+package monitor_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kn100/cybemu/cybemutest"
+	"github.com/kn100/cybemu/machine"
+	"github.com/kn100/cybemu/monitor"
+	"github.com/kn100/cybemu/rewind"
+	"github.com/stretchr/testify/assert"
+)
+
+// program returns a target running:
+//
+//	100: nop
+//	102: bsr 0x108
+//	104: nop
+//	106: (invalid)
+//	108: mov.b r1h, @0x40
+//	10a: rts
+func program() *cybemutest.Machine {
+	f := cybemutest.NewProgram([]byte{
+		0x00, 0x00,
+		0x55, 0x04,
+		0x00, 0x00,
+		0xFF, 0xFF,
+		0x31, 0x40,
+		0x54, 0x70,
+	})
+	f.Regs.ER[1] = 0x1234
+	return f
+}
+
+func run(t *testing.T, target machine.Target, commands ...string) string {
+	var out bytes.Buffer
+	err := monitor.New(target, &out).Run(strings.NewReader(strings.Join(commands, "\n")))
+	assert.NoError(t, err)
+	return out.String()
+}
+
+func TestStepAndNext(t *testing.T) {
+	target := program()
+	out := run(t, target, "step", "next")
+	assert.Contains(t, out, "=> 00102: 5504                      bsr 0x00000108:8")
+	assert.Contains(t, out, "=> 00104: 0000                      nop")
+	assert.Equal(t, uint32(0x104), target.Regs.PC)
+	assert.Equal(t, byte(0x12), target.Memory[0x40], "the call should have run")
+
+	target = program()
+	run(t, target, "step 2")
+	assert.Equal(t, uint32(0x108), target.Regs.PC, "step should go into calls")
+}
+
+func TestBreakpoints(t *testing.T) {
+	target := program()
+	out := run(t, target, "break 0x10a", "b 0x104", "b", "continue", "delete 0x10a", "delete 0x10a", "c")
+	assert.Contains(t, out, "breakpoint 0x000104\nbreakpoint 0x00010A\n")
+	assert.Contains(t, out, "breakpoint at 0x00010A")
+	assert.Contains(t, out, "breakpoint at 0x000104")
+	assert.Contains(t, out, "error: no breakpoint at 0x00010A")
+	assert.Equal(t, uint32(0x104), target.Regs.PC)
+
+	out = run(t, target, "c")
+	assert.Contains(t, out, "error: target stopped: invalid instruction")
+	assert.Equal(t, uint32(0x106), target.Regs.PC)
+}
+
+func TestWatchpoints(t *testing.T) {
+	target := program()
+	out := run(t, target, "watch 0x40", "watch", "c")
+	assert.Contains(t, out, "watchpoint 0x000040, 1 bytes")
+	assert.Contains(t, out, "watchpoint at 0x000040 changed to 12")
+	assert.Equal(t, uint32(0x10A), target.Regs.PC)
+
+	out = run(t, target, "unwatch 0x40", "unwatch 0x40", "watch 0x40 0", "watch")
+	assert.Contains(t, out, "error: no watchpoint at 0x000040")
+	assert.Contains(t, out, "error: a watchpoint must cover at least one byte")
+	assert.NotContains(t, out, "watchpoint 0x000040")
+}
+
+func TestReverse(t *testing.T) {
+	target := program()
+	r, err := rewind.New(target, 2)
+	assert.NoError(t, err)
+	out := run(t, r, "step 4", "rcontinue", "watch 0x40", "rcontinue", "rstep 2", "rc")
+	assert.Contains(t, out, "error: rcontinue needs a watchpoint to run back to")
+	assert.Contains(t, out, "=> 00108: 3140                      mov.b r1h, @0x40:8\n")
+	assert.Contains(t, out, "no earlier write to a watchpoint\n=> 00100: 0000")
+	assert.Equal(t, uint32(0x100), target.Regs.PC)
+	assert.Equal(t, byte(0), target.Memory[0x40])
+
+	out = run(t, program(), "rstep")
+	assert.Contains(t, out, "error: this target can't run backwards")
+}
+
+func TestRegisters(t *testing.T) {
+	target := program()
+	target.Regs.CCR = 0x85
+	out := run(t, target, "set er2 0xdeadbeef", "set pc er1", "regs", "set foo 1")
+	assert.Equal(t, uint32(0xDEADBEEF), target.Regs.ER[2])
+	assert.Equal(t, uint32(0x1234), target.Regs.PC)
+	assert.Contains(t, out, "er0 00000000  er1 00001234  er2 deadbeef  er3 00000000\n")
+	assert.Contains(t, out, "er4 00000000  er5 00000000  er6 00000000  sp  000001f0\n")
+	assert.Contains(t, out, "pc  001234    ccr 85 (I Z C)  exr 00\n")
+	assert.Contains(t, out, `error: unknown register "foo"`)
+}
+
+func TestMemoryAndDisassembly(t *testing.T) {
+	target := program()
+	copy(target.Memory[0x20:], "Cybiko!")
+	out := run(t, target, "x 0x20 8")
+	assert.Contains(t, out, "000020: 43 79 62 69 6b 6f 21 00                          |Cybiko!.|\n")
+
+	target.Regs.PC = 0x104
+	out = run(t, target, "dis 0x1FC 1", "dis pc 1", "dis")
+	assert.Contains(t, out, "=> 00104: 0000                      nop\n")
+	// Disassembly around pc starts a few instructions before it.
+	assert.Contains(t, out, "   00100: 0000                      nop\n   00102: 5504")
+	assert.Contains(t, out, "error: out of range")
+	assert.Contains(t, run(t, target, "x 0x1FF 2"), "error: out of range")
+}
+
+func TestQuit(t *testing.T) {
+	target := program()
+	out := run(t, target, "frobnicate", "quit", "step")
+	assert.Contains(t, out, `error: unknown command "frobnicate", try help`)
+	assert.Equal(t, uint32(0x100), target.Regs.PC, "quit should stop reading commands")
+}
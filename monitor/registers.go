@@ -0,0 +1,91 @@
+package monitor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kn100/cybemu/machine"
+)
+
+// ccrFlags names the bits of CCR, from bit 7 down to bit 0.
+var ccrFlags = []string{"I", "UI", "H", "U", "N", "Z", "V", "C"}
+
+// register gives access to a single register in a machine.Registers.
+type register struct {
+	get func() uint32
+	set func(uint32)
+}
+
+// registerByName returns the register called name in regs, or nil if there
+// isn't one.
+func registerByName(regs *machine.Registers, name string) *register {
+	name = strings.ToLower(name)
+	switch name {
+	case "pc":
+		return &register{
+			get: func() uint32 { return regs.PC },
+			set: func(v uint32) { regs.PC = v },
+		}
+	case "ccr":
+		return &register{
+			get: func() uint32 { return uint32(regs.CCR) },
+			set: func(v uint32) { regs.CCR = byte(v) },
+		}
+	case "exr":
+		return &register{
+			get: func() uint32 { return uint32(regs.EXR) },
+			set: func(v uint32) { regs.EXR = byte(v) },
+		}
+	case "sp":
+		name = "er7"
+	}
+	if !strings.HasPrefix(name, "er") {
+		return nil
+	}
+	n, err := strconv.Atoi(name[2:])
+	if err != nil || n < 0 || n > 7 {
+		return nil
+	}
+	return &register{
+		get: func() uint32 { return regs.ER[n] },
+		set: func(v uint32) { regs.ER[n] = v },
+	}
+}
+
+func (m *Monitor) setRegister(name string, value string) error {
+	regs := m.target.Registers()
+	r := registerByName(&regs, name)
+	if r == nil {
+		return fmt.Errorf("unknown register %q", name)
+	}
+	v, err := m.value(value)
+	if err != nil {
+		return err
+	}
+	r.set(v)
+	m.target.SetRegisters(regs)
+	return nil
+}
+
+func (m *Monitor) printRegisters() {
+	regs := m.target.Registers()
+	for n, er := range regs.ER {
+		name := fmt.Sprintf("er%d", n)
+		if n == 7 {
+			name = "sp"
+		}
+		sep := "  "
+		if n%4 == 3 {
+			sep = "\n"
+		}
+		fmt.Fprintf(m.out, "%-3s %08x%s", name, er, sep)
+	}
+	flags := []string{}
+	for bit, flag := range ccrFlags {
+		if regs.CCR&(0x80>>bit) != 0 {
+			flags = append(flags, flag)
+		}
+	}
+	fmt.Fprintf(m.out, "pc  %06x    ccr %02x (%s)  exr %02x\n", regs.PC, regs.CCR, strings.Join(flags, " "), regs.EXR)
+}
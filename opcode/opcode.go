@@ -96,3 +96,7 @@ const (
 	Xor
 	Xorc
 )
+
+// Count is the number of opcodes, so tables indexed by Opcode can be arrays.
+// It relies on Xorc being the last.
+const Count = int(Xorc) + 1
@@ -96,4 +96,10 @@ const (
 	AI32_R8
 	R8_AI32
 	I8_CCR
+	// @@aa:8, for jmp and jsr. C4PC doesn't have this one.
+	AAI8
+
+	// Count is the number of operand types, rather than one itself. New
+	// types go before it.
+	Count
 )
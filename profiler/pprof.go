@@ -0,0 +1,194 @@
+package profiler
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Field numbers from pprof's profile.proto.
+const (
+	profileSampleType  = 1
+	profileSample      = 2
+	profileMapping     = 3
+	profileLocation    = 4
+	profileFunction    = 5
+	profileStringTable = 6
+	profilePeriodType  = 11
+	profilePeriod      = 12
+
+	valueTypeType = 1
+	valueTypeUnit = 2
+
+	sampleLocationID = 1
+	sampleValue      = 2
+
+	mappingID           = 1
+	mappingMemoryStart  = 2
+	mappingMemoryLimit  = 3
+	mappingFilename     = 5
+	mappingHasFunctions = 7
+
+	locationID        = 1
+	locationMappingID = 2
+	locationAddress   = 3
+	locationLine      = 4
+
+	lineFunctionID = 1
+
+	functionID         = 1
+	functionName       = 2
+	functionSystemName = 3
+)
+
+// addressSpace is the size of the h8s/2000's address space in advanced mode.
+const addressSpace = 1 << 24
+
+// message builds a protocol buffer message. Nothing in a profile needs more
+// than the varint and length delimited wire types.
+type message []byte
+
+func (m *message) varint(x uint64) {
+	for x >= 0x80 {
+		*m = append(*m, byte(x)|0x80)
+		x >>= 7
+	}
+	*m = append(*m, byte(x))
+}
+
+// uint64 appends a varint field, which is left out if it is 0 as proto3
+// does.
+func (m *message) uint64(field int, x uint64) {
+	if x == 0 {
+		return
+	}
+	m.varint(uint64(field)<<3 | 0)
+	m.varint(x)
+}
+
+func (m *message) bytes(field int, b []byte) {
+	m.varint(uint64(field)<<3 | 2)
+	m.varint(uint64(len(b)))
+	*m = append(*m, b...)
+}
+
+func (m *message) packed(field int, xs []uint64) {
+	var p message
+	for _, x := range xs {
+		p.varint(x)
+	}
+	m.bytes(field, p)
+}
+
+// stringTable holds a profile's string table.
+type stringTable struct {
+	table []string
+	index map[string]uint64
+}
+
+func (s *stringTable) id(str string) uint64 {
+	if id, ok := s.index[str]; ok {
+		return id
+	}
+	s.index[str] = uint64(len(s.table))
+	s.table = append(s.table, str)
+	return s.index[str]
+}
+
+// DefaultName names a function after its address, for when there's no symbol
+// for it.
+func DefaultName(addr uint32) string {
+	return fmt.Sprintf("sub_%06x", addr)
+}
+
+// WritePprof writes everything recorded so far to w as a gzipped pprof
+// profile, with instructions executed and cycles as sample values. image names
+// the program being profiled, and name returns the name of the function
+// starting at an address; if it is nil, DefaultName is used.
+func (p *Profiler) WritePprof(w io.Writer, image string, name func(addr uint32) string) error {
+	if name == nil {
+		name = DefaultName
+	}
+	strs := &stringTable{index: map[string]uint64{}}
+	strs.id("")
+	var prof message
+
+	valueType := func(typ string, unit string) message {
+		var v message
+		v.uint64(valueTypeType, strs.id(typ))
+		v.uint64(valueTypeUnit, strs.id(unit))
+		return v
+	}
+	prof.bytes(profileSampleType, valueType("instructions", "count"))
+	prof.bytes(profileSampleType, valueType("cycles", "count"))
+
+	var mapping message
+	mapping.uint64(mappingID, 1)
+	mapping.uint64(mappingMemoryStart, 0)
+	mapping.uint64(mappingMemoryLimit, addressSpace)
+	mapping.uint64(mappingFilename, strs.id(image))
+	mapping.uint64(mappingHasFunctions, 1)
+	prof.bytes(profileMapping, mapping)
+
+	functions := map[uint32]uint64{}
+	type location struct{ addr, function uint32 }
+	locations := map[location]uint64{}
+	for _, s := range p.Samples() {
+		ids := make([]uint64, len(s.Stack))
+		for n, addr := range s.Stack {
+			loc := location{addr: addr, function: s.Functions[n]}
+			if locations[loc] == 0 {
+				locations[loc] = uint64(len(locations) + 1)
+			}
+			ids[n] = locations[loc]
+			if functions[loc.function] == 0 {
+				functions[loc.function] = uint64(len(functions) + 1)
+			}
+		}
+		var sample message
+		sample.packed(sampleLocationID, ids)
+		sample.packed(sampleValue, []uint64{s.Instructions, s.Cycles})
+		prof.bytes(profileSample, sample)
+	}
+
+	byID := make([]location, len(locations))
+	for loc, id := range locations {
+		byID[id-1] = loc
+	}
+	for n, loc := range byID {
+		var line message
+		line.uint64(lineFunctionID, functions[loc.function])
+		var l message
+		l.uint64(locationID, uint64(n+1))
+		l.uint64(locationMappingID, 1)
+		l.uint64(locationAddress, uint64(loc.addr))
+		l.bytes(locationLine, line)
+		prof.bytes(profileLocation, l)
+	}
+
+	addrs := make([]uint32, 0, len(functions))
+	for addr := range functions {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(a, b int) bool { return functions[addrs[a]] < functions[addrs[b]] })
+	for _, addr := range addrs {
+		var f message
+		f.uint64(functionID, functions[addr])
+		f.uint64(functionName, strs.id(name(addr)))
+		f.uint64(functionSystemName, strs.id(name(addr)))
+		prof.bytes(profileFunction, f)
+	}
+
+	prof.bytes(profilePeriodType, valueType("cycles", "count"))
+	prof.uint64(profilePeriod, 1)
+	for _, s := range strs.table {
+		prof.bytes(profileStringTable, []byte(s))
+	}
+
+	zw := gzip.NewWriter(w)
+	if _, err := zw.Write(prof); err != nil {
+		return err
+	}
+	return zw.Close()
+}
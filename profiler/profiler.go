@@ -0,0 +1,153 @@
+// contains a profiler for emulated programs, which attributes the cycles each
+// instruction takes to the call stack it was executed in, and writes the
+// result in the pprof format so it can be explored with go tool pprof.
+//
+// Emulation makes it cheap to account for every instruction rather than
+// sampling, so the profile is exact. The call stack is followed from bsr and
+// jsr, which push a frame, and from the stack pointer, which pops every frame
+// it has risen above. That way rts, rte and code which unwinds the stack
+// itself are all handled.
+package profiler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kn100/cybemu/disassembler"
+	"github.com/kn100/cybemu/instruction"
+	"github.com/kn100/cybemu/machine"
+)
+
+// Machine is a target which counts the cycles it has executed.
+type Machine interface {
+	machine.Target
+	Cycles() uint64
+}
+
+// frame is a function being executed.
+type frame struct {
+	// function is the address the function starts at.
+	function uint32
+	// caller is the address of the call which entered it.
+	caller uint32
+	// sp is the stack pointer once the call has pushed its return address.
+	sp uint32
+}
+
+// Sample is the cost of executing at one place.
+type Sample struct {
+	// Stack holds the address of the instruction executed, then the address
+	// of each call leading to it, innermost first.
+	Stack []uint32
+	// Functions holds the address of the function each entry in Stack is in.
+	Functions    []uint32
+	Cycles       uint64
+	Instructions uint64
+}
+
+// Profiler is a machine.Target which profiles the instructions it steps.
+type Profiler struct {
+	machine Machine
+	frames  []frame
+	samples map[string]*Sample
+}
+
+// New returns a Profiler for machine. The code being executed when it is
+// created is treated as a function starting at the current PC.
+func New(machine Machine) *Profiler {
+	return &Profiler{
+		machine: machine,
+		frames:  []frame{{function: machine.Registers().PC, sp: 0xFFFFFFFF}},
+		samples: map[string]*Sample{},
+	}
+}
+
+func (p *Profiler) Registers() machine.Registers {
+	return p.machine.Registers()
+}
+
+func (p *Profiler) SetRegisters(regs machine.Registers) {
+	p.machine.SetRegisters(regs)
+}
+
+func (p *Profiler) ReadMemory(addr uint32, buf []byte) error {
+	return p.machine.ReadMemory(addr, buf)
+}
+
+func (p *Profiler) WriteMemory(addr uint32, data []byte) error {
+	return p.machine.WriteMemory(addr, data)
+}
+
+// Step executes a single instruction, and records its cost if it completes.
+func (p *Profiler) Step() error {
+	pc := p.machine.Registers().PC
+	cycles := p.machine.Cycles()
+	call := p.isCall(pc)
+	if err := p.machine.Step(); err != nil {
+		return err
+	}
+	p.record(pc, p.machine.Cycles()-cycles)
+
+	regs := p.machine.Registers()
+	for len(p.frames) > 1 && p.frames[len(p.frames)-1].sp < regs.ER[7] {
+		p.frames = p.frames[:len(p.frames)-1]
+	}
+	if call {
+		p.frames = append(p.frames, frame{function: regs.PC, caller: pc, sp: regs.ER[7]})
+	}
+	return nil
+}
+
+func (p *Profiler) isCall(pc uint32) bool {
+	inst, err := disassembler.DecodeAt(p.machine, pc)
+	if err != nil {
+		return false
+	}
+	return inst.Class()&instruction.Call != 0
+}
+
+// record adds the cost of an instruction executed at pc in the current stack.
+func (p *Profiler) record(pc uint32, cycles uint64) {
+	// jsr through a register can call different functions from one place,
+	// so the functions are part of the key too.
+	var key strings.Builder
+	fmt.Fprintf(&key, "%x", pc)
+	for n := len(p.frames) - 1; n >= 0; n-- {
+		fmt.Fprintf(&key, " %x:%x", p.frames[n].function, p.frames[n].caller)
+	}
+	s := p.samples[key.String()]
+	if s == nil {
+		s = &Sample{Stack: []uint32{pc}}
+		for n := len(p.frames) - 1; n >= 0; n-- {
+			if n > 0 {
+				s.Stack = append(s.Stack, p.frames[n].caller)
+			}
+			s.Functions = append(s.Functions, p.frames[n].function)
+		}
+		p.samples[key.String()] = s
+	}
+	s.Cycles += cycles
+	s.Instructions++
+}
+
+// Samples returns everything recorded so far, most expensive first.
+func (p *Profiler) Samples() []Sample {
+	samples := make([]Sample, 0, len(p.samples))
+	for _, s := range p.samples {
+		samples = append(samples, *s)
+	}
+	sort.Slice(samples, func(a, b int) bool {
+		if samples[a].Cycles != samples[b].Cycles {
+			return samples[a].Cycles > samples[b].Cycles
+		}
+		x, y := samples[a].Stack, samples[b].Stack
+		for n := 0; n < len(x) && n < len(y); n++ {
+			if x[n] != y[n] {
+				return x[n] < y[n]
+			}
+		}
+		return len(x) < len(y)
+	})
+	return samples
+}
@@ -0,0 +1,86 @@
+package profiler_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/kn100/cybemu/cybemutest"
+	"github.com/kn100/cybemu/profiler"
+	"github.com/stretchr/testify/assert"
+)
+
+// program returns a machine running:
+//
+//	100: nop
+//	102: bsr 0x108
+//	104: nop
+//	106: (invalid)
+//	108: nop
+//	10a: bsr 0x10e
+//	10c: rts
+//	10e: nop
+//	110: rts
+func program() *cybemutest.Machine {
+	return cybemutest.NewProgram([]byte{
+		0x00, 0x00,
+		0x55, 0x04,
+		0x00, 0x00,
+		0xFF, 0xFF,
+		0x00, 0x00,
+		0x55, 0x02,
+		0x54, 0x70,
+		0x00, 0x00,
+		0x54, 0x70,
+	})
+}
+
+func profile(t *testing.T) *profiler.Profiler {
+	p := profiler.New(program())
+	for n := 0; ; n++ {
+		if p.Step() != nil {
+			break
+		}
+		assert.Less(t, n, 100)
+	}
+	return p
+}
+
+func TestSamples(t *testing.T) {
+	p := profile(t)
+	assert.Equal(t, []profiler.Sample{
+		{Stack: []uint32{0x10C, 0x102}, Functions: []uint32{0x108, 0x100}, Cycles: 8, Instructions: 1},
+		{Stack: []uint32{0x110, 0x10A, 0x102}, Functions: []uint32{0x10E, 0x108, 0x100}, Cycles: 8, Instructions: 1},
+		{Stack: []uint32{0x102}, Functions: []uint32{0x100}, Cycles: 6, Instructions: 1},
+		{Stack: []uint32{0x10A, 0x102}, Functions: []uint32{0x108, 0x100}, Cycles: 6, Instructions: 1},
+		{Stack: []uint32{0x100}, Functions: []uint32{0x100}, Cycles: 2, Instructions: 1},
+		{Stack: []uint32{0x104}, Functions: []uint32{0x100}, Cycles: 2, Instructions: 1},
+		{Stack: []uint32{0x108, 0x102}, Functions: []uint32{0x108, 0x100}, Cycles: 2, Instructions: 1},
+		{Stack: []uint32{0x10E, 0x10A, 0x102}, Functions: []uint32{0x10E, 0x108, 0x100}, Cycles: 2, Instructions: 1},
+	}, p.Samples())
+}
+
+func TestWritePprof(t *testing.T) {
+	p := profile(t)
+	var buf bytes.Buffer
+	names := map[uint32]string{0x100: "main", 0x108: "draw"}
+	err := p.WritePprof(&buf, "boot.bin", func(addr uint32) string {
+		if name, ok := names[addr]; ok {
+			return name
+		}
+		return profiler.DefaultName(addr)
+	})
+	assert.NoError(t, err)
+
+	zr, err := gzip.NewReader(&buf)
+	assert.NoError(t, err)
+	raw, err := io.ReadAll(zr)
+	assert.NoError(t, err)
+	// The first field is the instructions sample type, whose strings are 1
+	// and 2 in the string table.
+	assert.Equal(t, []byte{0x0A, 0x04, 0x08, 0x01, 0x10, 0x02}, raw[:6])
+	for _, s := range []string{"instructions", "cycles", "boot.bin", "main", "draw", "sub_00010e"} {
+		assert.Contains(t, string(raw), s)
+	}
+}
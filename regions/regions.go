@@ -0,0 +1,244 @@
+// contains a classifier which splits a loaded image into code and the data
+// that would otherwise be disassembled as if it were code: the exception
+// vector table, null terminated strings, and tables of pointers into the
+// image.
+package regions
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kn100/cybemu/instruction"
+	"github.com/kn100/cybemu/loader"
+	"github.com/kn100/cybemu/opcode"
+)
+
+// Kind is what a region holds.
+type Kind int
+
+const (
+	Code Kind = iota
+	// Vectors is the exception vector table.
+	Vectors
+	// ASCII is one or more null terminated strings, and any nulls after them
+	// which keep what follows aligned.
+	ASCII
+	// Pointers is a table of longwords holding addresses in the image.
+	Pointers
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Code:
+		return "code"
+	case Vectors:
+		return "vectors"
+	case ASCII:
+		return "ascii"
+	case Pointers:
+		return "pointers"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+const (
+	// minString is the fewest characters a string can have. Shorter runs of
+	// printable bytes are too common in code.
+	minString = 4
+	// minPointers is the fewest entries a pointer table can have.
+	minPointers = 3
+	addressMask = 0x00FFFFFF
+)
+
+// Region is a run of bytes in an image holding one kind of thing.
+type Region struct {
+	Addr uint32
+	Data []byte
+	Kind Kind
+}
+
+// End returns the address just past the last byte of the region.
+func (r Region) End() uint32 {
+	return r.Addr + uint32(len(r.Data))
+}
+
+// Map is the regions of an image, in address order.
+type Map []Region
+
+// Classify splits every segment of image into regions. It guesses: strings
+// must be mostly letters, digits and spaces, and pointer tables must have at
+// least three entries, so short ones are left as code. Data only starts where
+// code can't run into it, so that operands aren't mistaken for it.
+func Classify(image *loader.Image) Map {
+	var m Map
+	for _, seg := range image.Segments {
+		m = append(m, classify(image, seg)...)
+	}
+	return m
+}
+
+func classify(image *loader.Image, seg loader.Segment) []Region {
+	var regions []Region
+	add := func(start, end uint32, k Kind) {
+		if end > start {
+			regions = append(regions, Region{Addr: start, Data: seg.Data[start-seg.Addr : end-seg.Addr], Kind: k})
+		}
+	}
+
+	addr := seg.Addr
+	if image.Kind == loader.BootROM && seg.Addr == 0 {
+		addr = vectorTableEnd(image, seg)
+		add(seg.Addr, addr, Vectors)
+	}
+	code := addr
+	// Data is only looked for where no instruction runs into it: at the
+	// start of code, or after an instruction which doesn't fall through and
+	// any nops padding after it. Elsewhere, bytes which look like data are
+	// more likely operands, so the code is stepped through an instruction
+	// at a time.
+	canStart := true
+	for addr < seg.End() {
+		b := seg.Data[addr-seg.Addr:]
+		if canStart {
+			k, n := ASCII, asciiLength(b)
+			if n == 0 {
+				k, n = Pointers, pointersLength(image, b)
+			}
+			if n > 0 {
+				add(code, addr, Code)
+				add(addr, addr+uint32(n), k)
+				addr += uint32(n)
+				code = addr
+				continue
+			}
+		}
+		inst := instruction.Decode(b)
+		addr += uint32(inst.TotalBytes)
+		canStart = !inst.FallsThrough() || canStart && inst.Opcode == opcode.Nop
+	}
+	add(code, seg.End(), Code)
+	return regions
+}
+
+// vectorTableEnd returns the address just past the vector table at the start
+// of seg. It runs until the first longword which can't be a vector, or the
+// first code a named vector points to.
+func vectorTableEnd(image *loader.Image, seg loader.Segment) uint32 {
+	limit := seg.End()
+	if entries := image.EntryPoints(); len(entries) > 0 && entries[0] < limit {
+		limit = entries[0]
+	}
+	end := seg.Addr
+	for end+loader.VectorSize <= limit {
+		v := read32(seg.Data[end-seg.Addr:])
+		if v&^addressMask != 0 || v&1 != 0 {
+			break
+		}
+		end += loader.VectorSize
+	}
+	return end
+}
+
+// asciiLength returns the length of the strings at the start of b, including
+// their nulls and any padding after them, or 0 if there aren't any.
+func asciiLength(b []byte) int {
+	var ends []int
+	n := 0
+	for {
+		l := stringLength(b[n:])
+		if l == 0 {
+			break
+		}
+		n += l
+		ends = append(ends, n)
+	}
+	// b starts at an even address, and so must whatever follows. If the
+	// last string can't be padded to one, it's dropped.
+	for len(ends) > 0 {
+		end := ends[len(ends)-1]
+		if end%2 != 0 && end < len(b) && b[end] == 0 {
+			end++
+		}
+		if end%2 == 0 {
+			return end
+		}
+		ends = ends[:len(ends)-1]
+	}
+	return 0
+}
+
+// stringLength returns the length of the null terminated string at the start
+// of b, including the null, or 0 if it doesn't look like one.
+func stringLength(b []byte) int {
+	words := 0
+	for n, c := range b {
+		switch {
+		case c == 0:
+			// Allow a few punctuation marks, but most should be words.
+			if n < minString || words*4 < n*3 {
+				return 0
+			}
+			return n + 1
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == ' ':
+			words++
+		case c >= 0x20 && c < 0x7F, c == '\t', c == '\n', c == '\r':
+		default:
+			return 0
+		}
+	}
+	return 0
+}
+
+// pointersLength returns the length of the table of pointers into image at
+// the start of b, or 0 if there isn't one.
+func pointersLength(image *loader.Image, b []byte) int {
+	n := 0
+	for n+4 <= len(b) && isPointer(image, read32(b[n:])) {
+		n += 4
+	}
+	if n < minPointers*4 {
+		return 0
+	}
+	return n
+}
+
+// isPointer reports whether v could be a pointer into image. Null pointers
+// are common in tables, but then so are zeroes in code, so they don't count.
+func isPointer(image *loader.Image, v uint32) bool {
+	if v == 0 || v&^addressMask != 0 {
+		return false
+	}
+	_, ok := image.Read(v, 1)
+	return ok
+}
+
+func read32(b []byte) uint32 {
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// At returns the region holding addr.
+func (m Map) At(addr uint32) (Region, bool) {
+	n := sort.Search(len(m), func(n int) bool { return m[n].End() > addr })
+	if n < len(m) && m[n].Addr <= addr {
+		return m[n], true
+	}
+	return Region{}, false
+}
+
+// Name returns a name for addr, made from the kind of region it is in and
+// the address, such as str_001234 for a string. Addresses outside the image
+// and in code are named loc_.
+func (m Map) Name(addr uint32) string {
+	prefix := "loc"
+	if r, ok := m.At(addr); ok {
+		switch r.Kind {
+		case Vectors:
+			prefix = "vec"
+		case ASCII:
+			prefix = "str"
+		case Pointers:
+			prefix = "off"
+		}
+	}
+	return fmt.Sprintf("%s_%06x", prefix, addr)
+}
@@ -0,0 +1,107 @@
+package regions_test
+
+import (
+	"testing"
+
+	"github.com/kn100/cybemu/loader"
+	"github.com/kn100/cybemu/regions"
+	"github.com/stretchr/testify/assert"
+)
+
+// rom returns a boot ROM image whose vectors all point to code at 0x100,
+// followed by a pointer table and two strings.
+func rom() []byte {
+	b := make([]byte, 0x124)
+	for _, v := range loader.Vectors {
+		copy(b[v.Address():], []byte{0x00, 0x00, 0x01, 0x00})
+	}
+	copy(b[0x100:], []byte{
+		0x5E, 0x00, 0x01, 0x22, // jsr @0x000122:24
+		0x54, 0x70, // rts
+		0x00, 0x00, // nop
+		0x00, 0x00, 0x01, 0x14, // 0x108: pointers
+		0x00, 0x00, 0x01, 0x00,
+		0x00, 0x00, 0x01, 0x22,
+	})
+	copy(b[0x114:], "Hello\x00Cybiko\x00\x00")
+	copy(b[0x122:], []byte{0x54, 0x70})
+	return b
+}
+
+func TestClassify(t *testing.T) {
+	image, err := loader.Load(rom())
+	assert.NoError(t, err)
+	m := regions.Classify(image)
+
+	type span struct {
+		addr, end uint32
+		kind      regions.Kind
+	}
+	var spans []span
+	for _, r := range m {
+		spans = append(spans, span{r.Addr, r.End(), r.Kind})
+	}
+	assert.Equal(t, []span{
+		{0x000, 0x100, regions.Vectors},
+		{0x100, 0x108, regions.Code},
+		{0x108, 0x114, regions.Pointers},
+		{0x114, 0x122, regions.ASCII},
+		{0x122, 0x124, regions.Code},
+	}, spans)
+
+	r, ok := m.At(0x11A)
+	assert.True(t, ok)
+	assert.Equal(t, regions.ASCII, r.Kind)
+	_, ok = m.At(0x124)
+	assert.False(t, ok)
+
+	assert.Equal(t, "str_00011a", m.Name(0x11A))
+	assert.Equal(t, "loc_000100", m.Name(0x100))
+	assert.Equal(t, "off_00010c", m.Name(0x10C))
+	assert.Equal(t, "loc_ffff00", m.Name(0xFFFF00))
+}
+
+func TestClassifyRaw(t *testing.T) {
+	// Too short to be strings, and not pointers into the image.
+	image, err := loader.Load([]byte{'a', 'b', 'c', 0x00, 0x00, 0x00, 0x00, 0x02, 0x54, 0x70})
+	assert.NoError(t, err)
+	m := regions.Classify(image)
+	assert.Len(t, m, 1)
+	assert.Equal(t, regions.Code, m[0].Kind)
+}
+
+func TestClassifyOperands(t *testing.T) {
+	// Pointers into the image, but as the operands of instructions rather
+	// than a table, and not after code which doesn't fall through.
+	b := make([]byte, 0x1000)
+	copy(b, []byte{
+		0x79, 0x00, 0x00, 0x00, // mov.w #0x0000, r0
+		0x0C, 0x12, // mov.b r1h, r2h
+		0x00, 0x00, // nop
+		0x0C, 0x12,
+		0x00, 0x00,
+		0x0C, 0x12,
+	})
+	image, err := loader.Load(b)
+	assert.NoError(t, err)
+	m := regions.Classify(image)
+	assert.Len(t, m, 1)
+	assert.Equal(t, regions.Code, m[0].Kind)
+}
+
+func TestClassifyAfterSleep(t *testing.T) {
+	// Execution carries on after sleep once the interrupt which wakes it
+	// returns, so what follows it is code.
+	b := make([]byte, 0x1000)
+	copy(b, []byte{
+		0x01, 0x80, // sleep
+		0x00, 0x00, 0x0C, 0x12,
+		0x00, 0x00, 0x0C, 0x12,
+		0x00, 0x00, 0x0C, 0x12,
+	})
+	image, err := loader.Load(b)
+	assert.NoError(t, err)
+	m := regions.Classify(image)
+	assert.Len(t, m, 1)
+	assert.Equal(t, regions.Code, m[0].Kind)
+}
@@ -0,0 +1,206 @@
+// contains a log of every external input an emulated machine receives, with
+// the cycle it arrived at, so a session can be recorded and later replayed
+// exactly. Combined with a snapshot to start from, a replayed log reproduces a
+// run bit for bit.
+//
+// Key presses and received serial bytes are pushed into the machine by the
+// outside world: while replaying, the machine asks for the inputs Due at each
+// cycle instead of looking at the keyboard or serial port. Reads of the real
+// time clock are pulled by the emulated code: while replaying, the machine
+// asks for the recorded value instead of reading the host's clock, and the
+// replay is known to have diverged if the read doesn't happen at the same
+// cycle it was recorded at.
+//
+// A log starts with the 4 byte magic "CYRR" and a version byte (currently 1),
+// followed by one entry per input: a uvarint holding the number of cycles
+// since the previous input (or since cycle 0 for the first), a byte holding
+// the kind, and a uvarint holding the value.
+package replay
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Magic starts every input log.
+const Magic = "CYRR"
+
+// Version is the version of the log format written by Recorder.
+const Version = 1
+
+// Kind is the kind of an input.
+type Kind byte
+
+const (
+	// KeyDown is a key being pressed. Value is the key code.
+	KeyDown Kind = iota + 1
+	// KeyUp is a key being released. Value is the key code.
+	KeyUp
+	// Serial is a byte received on a serial port. Value is the byte.
+	Serial
+	// RTC is a read of the real time clock. Value is what was read.
+	RTC
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KeyDown:
+		return "key down"
+	case KeyUp:
+		return "key up"
+	case Serial:
+		return "serial"
+	case RTC:
+		return "rtc"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Input is a single external input.
+type Input struct {
+	Cycle uint64
+	Kind  Kind
+	Value uint64
+}
+
+// ErrDiverged is returned when a replay no longer matches the recording.
+var ErrDiverged = errors.New("replay diverged from the recording")
+
+// Recorder writes inputs to a log as they happen.
+type Recorder struct {
+	w    *bufio.Writer
+	last uint64
+	buf  [binary.MaxVarintLen64]byte
+}
+
+// NewRecorder returns a Recorder writing to w, having written the header.
+// Flush must be called once the last input is recorded.
+func NewRecorder(w io.Writer) (*Recorder, error) {
+	r := &Recorder{w: bufio.NewWriter(w)}
+	if _, err := r.w.WriteString(Magic); err != nil {
+		return nil, err
+	}
+	if err := r.w.WriteByte(Version); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Record appends in to the log. Inputs must be recorded in the order they
+// arrive, so in.Cycle can't be before the previous input's.
+func (r *Recorder) Record(in Input) error {
+	if in.Cycle < r.last {
+		return fmt.Errorf("input at cycle %d recorded after one at cycle %d", in.Cycle, r.last)
+	}
+	n := binary.PutUvarint(r.buf[:], in.Cycle-r.last)
+	r.w.Write(r.buf[:n])
+	r.w.WriteByte(byte(in.Kind))
+	n = binary.PutUvarint(r.buf[:], in.Value)
+	_, err := r.w.Write(r.buf[:n])
+	r.last = in.Cycle
+	return err
+}
+
+// Flush writes any buffered inputs to the underlying io.Writer.
+func (r *Recorder) Flush() error {
+	return r.w.Flush()
+}
+
+// ReadLog reads every input from a log.
+func ReadLog(r io.Reader) ([]Input, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(Magic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("couldn't read input log header: %w", err)
+	}
+	if string(header[:len(Magic)]) != Magic {
+		return nil, errors.New("not an input log")
+	}
+	if header[len(Magic)] != Version {
+		return nil, fmt.Errorf("unsupported input log version %d", header[len(Magic)])
+	}
+
+	inputs := []Input{}
+	cycle := uint64(0)
+	for {
+		delta, err := binary.ReadUvarint(br)
+		if err == io.EOF {
+			return inputs, nil
+		} else if err != nil {
+			return nil, err
+		}
+		kind, err := br.ReadByte()
+		if err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		value, err := binary.ReadUvarint(br)
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		} else if err != nil {
+			return nil, err
+		}
+		cycle += delta
+		inputs = append(inputs, Input{Cycle: cycle, Kind: Kind(kind), Value: value})
+	}
+}
+
+// Replayer hands a machine the inputs from a recording.
+type Replayer struct {
+	pushed []Input
+	pulled []Input
+}
+
+// NewReplayer returns a Replayer for inputs, which must be in the order they
+// were recorded.
+func NewReplayer(inputs []Input) *Replayer {
+	r := &Replayer{}
+	for _, in := range inputs {
+		if in.Kind == RTC {
+			r.pulled = append(r.pulled, in)
+		} else {
+			r.pushed = append(r.pushed, in)
+		}
+	}
+	return r
+}
+
+// Due returns, in order, the key and serial inputs which arrive at or before
+// cycle and haven't already been returned.
+func (r *Replayer) Due(cycle uint64) []Input {
+	n := 0
+	for n < len(r.pushed) && r.pushed[n].Cycle <= cycle {
+		n++
+	}
+	due := r.pushed[:n]
+	r.pushed = r.pushed[n:]
+	return due
+}
+
+// NextDue returns the cycle the next key or serial input arrives at, so the
+// machine can run up to it without asking at every cycle. It returns false if
+// there are no more.
+func (r *Replayer) NextDue() (uint64, bool) {
+	if len(r.pushed) == 0 {
+		return 0, false
+	}
+	return r.pushed[0].Cycle, true
+}
+
+// ReadRTC returns the value the real time clock was read as at cycle. It
+// returns ErrDiverged if the recording didn't read the clock at that cycle.
+func (r *Replayer) ReadRTC(cycle uint64) (uint64, error) {
+	if len(r.pulled) == 0 || r.pulled[0].Cycle != cycle {
+		return 0, fmt.Errorf("%w: unexpected real time clock read at cycle %d", ErrDiverged, cycle)
+	}
+	v := r.pulled[0].Value
+	r.pulled = r.pulled[1:]
+	return v, nil
+}
+
+// Done returns true once every input has been handed out.
+func (r *Replayer) Done() bool {
+	return len(r.pushed) == 0 && len(r.pulled) == 0
+}
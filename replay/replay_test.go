@@ -0,0 +1,69 @@
+package replay_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/kn100/cybemu/replay"
+	"github.com/stretchr/testify/assert"
+)
+
+func testInputs() []replay.Input {
+	return []replay.Input{
+		{Cycle: 100, Kind: replay.KeyDown, Value: 0x41},
+		{Cycle: 100, Kind: replay.RTC, Value: 1000000000},
+		{Cycle: 250, Kind: replay.KeyUp, Value: 0x41},
+		{Cycle: 300, Kind: replay.Serial, Value: 0x7E},
+		{Cycle: 1 << 40, Kind: replay.Serial, Value: 0x00},
+	}
+}
+
+func TestRecordAndRead(t *testing.T) {
+	var log bytes.Buffer
+	r, err := replay.NewRecorder(&log)
+	assert.NoError(t, err)
+	for _, in := range testInputs() {
+		assert.NoError(t, r.Record(in))
+	}
+	assert.Error(t, r.Record(replay.Input{Cycle: 5, Kind: replay.KeyDown}), "inputs must be recorded in order")
+	assert.NoError(t, r.Flush())
+	assert.Equal(t, []byte("CYRR\x01\x64\x01\x41"), log.Bytes()[:8])
+
+	inputs, err := replay.ReadLog(bytes.NewReader(log.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, testInputs(), inputs)
+
+	_, err = replay.ReadLog(bytes.NewReader(log.Bytes()[:log.Len()-1]))
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	_, err = replay.ReadLog(bytes.NewReader([]byte("CYRR\x07")))
+	assert.EqualError(t, err, "unsupported input log version 7")
+	_, err = replay.ReadLog(bytes.NewReader([]byte("LOGS\x01")))
+	assert.EqualError(t, err, "not an input log")
+}
+
+func TestReplay(t *testing.T) {
+	r := replay.NewReplayer(testInputs())
+
+	next, ok := r.NextDue()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), next)
+	assert.Empty(t, r.Due(99))
+	assert.Equal(t, []replay.Input{{Cycle: 100, Kind: replay.KeyDown, Value: 0x41}}, r.Due(100))
+
+	v, err := r.ReadRTC(100)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000000000), v)
+	_, err = r.ReadRTC(120)
+	assert.ErrorIs(t, err, replay.ErrDiverged)
+
+	assert.Equal(t, []replay.Input{
+		{Cycle: 250, Kind: replay.KeyUp, Value: 0x41},
+		{Cycle: 300, Kind: replay.Serial, Value: 0x7E},
+	}, r.Due(1000))
+	assert.False(t, r.Done())
+	assert.Len(t, r.Due(1<<40), 1)
+	assert.True(t, r.Done())
+	_, ok = r.NextDue()
+	assert.False(t, ok)
+}
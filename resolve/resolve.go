@@ -0,0 +1,257 @@
+// contains a constant propagation pass over disassembled code, which finds
+// the targets of jumps and calls through registers, such as mov.l #f, er0
+// followed by jsr @er0, and through the vectors read by @@aa:8.
+//
+// The targets found are only used for cross references so far. There's no
+// recursive descent disassembler or call graph to feed them to: everything
+// regions doesn't take for data is disassembled by linear sweep, so no code
+// is left unreached for them to find.
+package resolve
+
+import (
+	"sort"
+
+	"github.com/kn100/cybemu/instruction"
+	"github.com/kn100/cybemu/opcode"
+	"github.com/kn100/cybemu/size"
+	"github.com/kn100/cybemu/xref"
+)
+
+// Memory holds the vectors read by @@aa:8. *loader.Image is one.
+type Memory interface {
+	Read32(addr uint32) (uint32, bool)
+}
+
+// value is what is known about a register.
+type value struct {
+	known bool
+	v     uint32
+}
+
+// state is what is known about er0-er7 before an instruction. Instructions
+// not yet reached have no state.
+type state struct {
+	reached bool
+	regs    [8]value
+}
+
+// Resolve returns a Call or Jump reference for every jsr or jmp in
+// instructions whose target it can work out. instructions must be in address
+// order, with their operands set, as DisassembleAt returns them. mem may be
+// nil, in which case @@aa:8 isn't resolved.
+//
+// Values are only followed within a function: nothing is known at the start
+// of instructions, at anything called, or after anything which doesn't carry
+// on to the next instruction, and calls are assumed to change every register.
+func Resolve(instructions []instruction.Inst, mem Memory) []xref.Ref {
+	index := make(map[int]int, len(instructions))
+	for n, inst := range instructions {
+		index[inst.Pos] = n
+	}
+	states := make([]state, len(instructions))
+	var work []int
+	merge := func(n int, s state) {
+		if states[n].reached {
+			changed := false
+			for r := range s.regs {
+				if states[n].regs[r].known && states[n].regs[r] != s.regs[r] {
+					states[n].regs[r] = value{}
+					changed = true
+				}
+			}
+			if !changed {
+				return
+			}
+		} else {
+			states[n] = s
+			states[n].reached = true
+		}
+		work = append(work, n)
+	}
+
+	entry := state{}
+	for n, inst := range instructions {
+		if n == 0 || !instructions[n-1].FallsThrough() {
+			merge(n, entry)
+		}
+		if inst.Class()&instruction.Call != 0 {
+			if t, ok := target(&inst); ok {
+				if m, ok := index[t]; ok {
+					merge(m, entry)
+				}
+			}
+		}
+	}
+
+	for len(work) > 0 {
+		n := work[len(work)-1]
+		work = work[:len(work)-1]
+		inst := &instructions[n]
+		out := states[n]
+		out.step(inst)
+		if inst.FallsThrough() && n+1 < len(instructions) {
+			merge(n+1, out)
+		}
+		if inst.Class()&instruction.Branch != 0 {
+			if t, ok := target(inst); ok {
+				if m, ok := index[t]; ok {
+					merge(m, out)
+				}
+			}
+		}
+	}
+
+	var refs []xref.Ref
+	for n := range instructions {
+		inst := &instructions[n]
+		kind := xref.Jump
+		switch {
+		case inst.Opcode == opcode.Jsr:
+			kind = xref.Call
+		case inst.Opcode != opcode.Jmp:
+			continue
+		}
+		ops := inst.Operands()
+		if len(ops) != 1 || !states[n].reached {
+			continue
+		}
+		var to uint32
+		if op, ok := ops[0].Indirect(); ok {
+			r := states[n].regs[op.Register]
+			if !r.known {
+				continue
+			}
+			to = r.v
+		} else if op, ok := ops[0].MemoryIndirect(); ok && mem != nil {
+			v, ok := mem.Read32(uint32(op.Address))
+			if !ok {
+				continue
+			}
+			to = v
+		} else {
+			continue
+		}
+		refs = append(refs, xref.Ref{From: uint32(inst.Pos), To: to & 0xFFFFFF, Kind: kind})
+	}
+	sort.Slice(refs, func(a, b int) bool { return refs[a].From < refs[b].From })
+	return refs
+}
+
+// target returns the address a pc relative branch or call to an absolute
+// address goes to.
+func target(inst *instruction.Inst) (int, bool) {
+	ops := inst.Operands()
+	if len(ops) != 1 {
+		return 0, false
+	}
+	if op, ok := ops[0].PCRelative(); ok {
+		return op.Target & 0xFFFFFF, true
+	}
+	if op, ok := ops[0].Absolute(); ok {
+		return int(op.EffectiveAddress()), true
+	}
+	return 0, false
+}
+
+// step updates s to what is known after inst runs.
+func (s *state) step(inst *instruction.Inst) {
+	if inst.Class()&(instruction.Call|instruction.Trap) != 0 {
+		s.regs = [8]value{}
+		return
+	}
+	n, v, ok := s.evaluate(inst)
+	written := inst.Writes()
+	for r := range s.regs {
+		if written.Has(uint8(r)) {
+			s.regs[r] = value{}
+		}
+	}
+	if ok {
+		s.regs[n] = value{known: true, v: v}
+	}
+}
+
+// evaluate returns the register inst writes and the value it leaves in all
+// of it, if inst is one of the few instructions followed and everything it
+// depends on is known.
+func (s *state) evaluate(inst *instruction.Inst) (uint8, uint32, bool) {
+	ops := inst.Operands()
+	if len(ops) != 2 {
+		return 0, 0, false
+	}
+	dst, ok := ops[1].Register()
+	if !ok {
+		return 0, 0, false
+	}
+	src, ok := s.operand(ops[0])
+	if !ok {
+		return 0, 0, false
+	}
+	if inst.Opcode != opcode.Mov {
+		old, ok := s.get(dst)
+		if !ok {
+			return 0, 0, false
+		}
+		switch inst.Opcode {
+		case opcode.Add, opcode.Adds:
+			src = old + src
+		case opcode.Sub, opcode.Subs:
+			src = old - src
+		default:
+			return 0, 0, false
+		}
+	}
+	return s.merged(dst, src)
+}
+
+// operand returns the value of a source operand, if it is known.
+func (s *state) operand(op instruction.Operand) (uint32, bool) {
+	if i, ok := op.Immediate(); ok {
+		return i.Value, true
+	}
+	if r, ok := op.Register(); ok {
+		return s.get(r)
+	}
+	return 0, false
+}
+
+// get returns the value of r, if it is known.
+func (s *state) get(r instruction.Register) (uint32, bool) {
+	reg := s.regs[r.Number&7]
+	if !reg.known {
+		return 0, false
+	}
+	mask, shift := part(r)
+	return reg.v >> shift & mask, true
+}
+
+// merged returns the number of the register r is part of, and its value
+// once v is written to r. Writing part of a register whose value isn't known
+// leaves it unknown.
+func (s *state) merged(r instruction.Register, v uint32) (uint8, uint32, bool) {
+	n := r.Number & 7
+	mask, shift := part(r)
+	if r.Size == size.Longword {
+		return n, v, true
+	}
+	old := s.regs[n]
+	if !old.known {
+		return 0, 0, false
+	}
+	return n, old.v&^(mask<<shift) | (v&mask)<<shift, true
+}
+
+// part returns where r is in the longword register it is part of.
+func part(r instruction.Register) (mask, shift uint32) {
+	switch {
+	case r.Size == size.Longword:
+		return 0xFFFFFFFF, 0
+	case r.Size == size.Byte && r.Number < 8:
+		return 0xFF, 8
+	case r.Size == size.Byte:
+		return 0xFF, 0
+	case r.Number < 8:
+		return 0xFFFF, 0
+	}
+	return 0xFFFF, 16
+}
@@ -0,0 +1,52 @@
+package resolve_test
+
+import (
+	"testing"
+
+	"github.com/kn100/cybemu/disassembler"
+	"github.com/kn100/cybemu/resolve"
+	"github.com/kn100/cybemu/xref"
+	"github.com/stretchr/testify/assert"
+)
+
+// vectors is a Memory holding only the longwords in it.
+type vectors map[uint32]uint32
+
+func (v vectors) Read32(addr uint32) (uint32, bool) {
+	value, ok := v[addr]
+	return value, ok
+}
+
+func TestResolve(t *testing.T) {
+	code := []byte{
+		0x7A, 0x00, 0x00, 0x00, 0x01, 0x00, // 000: mov.l #0x00000100, er0
+		0x5D, 0x00, // 006: jsr @er0
+		0x5D, 0x00, // 008: jsr @er0, after the call changed er0
+		0x7A, 0x01, 0x00, 0x00, 0x02, 0x00, // 00A: mov.l #0x00000200, er1
+		0x0B, 0x91, // 010: adds #4, er1
+		0xF9, 0x10, // 012: mov.b #0x10, r1l
+		0x59, 0x10, // 014: jmp @er1
+		0x5F, 0x20, // 016: jsr @@0x20:8
+	}
+	refs := resolve.Resolve(disassembler.Disassemble(code), vectors{0x20: 0xFF001234})
+	assert.Equal(t, []xref.Ref{
+		{From: 0x006, To: 0x100, Kind: xref.Call},
+		{From: 0x014, To: 0x210, Kind: xref.Jump},
+		{From: 0x016, To: 0x1234, Kind: xref.Call},
+	}, refs)
+}
+
+func TestResolveMerge(t *testing.T) {
+	code := []byte{
+		0x7A, 0x00, 0x00, 0x00, 0x01, 0x00, // 000: mov.l #0x00000100, er0
+		0x47, 0x06, // 006: beq 0x00E
+		0x7A, 0x00, 0x00, 0x00, 0x02, 0x00, // 008: mov.l #0x00000200, er0
+		0x5D, 0x00, // 00E: jsr @er0, which could be either
+		0x7A, 0x03, 0x00, 0x00, 0x04, 0x00, // 010: mov.l #0x00000400, er3
+		0x46, 0x00, // 016: bne 0x018
+		0x59, 0x30, // 018: jmp @er3, which is the same either way
+		0x59, 0x30, // 01A: jmp @er3, which nothing runs into
+	}
+	refs := resolve.Resolve(disassembler.Disassemble(code), nil)
+	assert.Equal(t, []xref.Ref{{From: 0x018, To: 0x400, Kind: xref.Jump}}, refs)
+}
@@ -0,0 +1,262 @@
+// contains reverse execution for deterministic machines. A Rewinder steps a
+// machine forward, snapshotting it every so often. Going backwards is done by
+// restoring the closest snapshot before the wanted position and running
+// forward from it again, which gives the same result as the first time as long
+// as the machine is deterministic: it must replay its external inputs from a
+// replay log rather than take them live.
+//
+// Only MaxCheckpoints snapshots are kept. Once there are more, older ones are
+// dropped so that they thin out exponentially with age: going back a little
+// way stays quick, while going back a long way means running further forward
+// again.
+//
+// Positions are counted in instructions executed since the Rewinder was
+// created, starting from 0.
+package rewind
+
+import (
+	"errors"
+	"math"
+
+	"github.com/kn100/cybemu/machine"
+	"github.com/kn100/cybemu/snapshot"
+)
+
+// DefaultInterval is a reasonable number of instructions between snapshots.
+// Shorter intervals make going backwards quicker, at the cost of memory.
+const DefaultInterval = 10000
+
+// MaxCheckpoints is the most snapshots a Rewinder keeps.
+const MaxCheckpoints = 64
+
+// ErrNoWrite is returned by ReverseToWrite when none of the watched memory
+// has been written since the Rewinder was created.
+var ErrNoWrite = errors.New("no earlier write to watched memory")
+
+// Machine is a target which can be snapshotted, and which always does the same
+// thing when stepped from the same snapshot.
+type Machine interface {
+	machine.Target
+	snapshot.Machine
+}
+
+// Watch is a range of memory watched for writes.
+type Watch struct {
+	Addr   uint32
+	Length int
+}
+
+type checkpoint struct {
+	pos  uint64
+	snap *snapshot.Snapshot
+}
+
+// Rewinder is a machine.Target which can also run backwards.
+type Rewinder struct {
+	machine     Machine
+	interval    uint64
+	pos         uint64
+	checkpoints []checkpoint
+}
+
+// New returns a Rewinder for machine, snapshotting it every interval
+// instructions. The machine's current state is position 0, and is as far back
+// as the Rewinder can go.
+func New(machine Machine, interval int) (*Rewinder, error) {
+	if interval < 1 {
+		return nil, errors.New("snapshot interval must be at least 1")
+	}
+	r := &Rewinder{machine: machine, interval: uint64(interval)}
+	if err := r.checkpoint(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Position returns the number of instructions executed to reach the current
+// state.
+func (r *Rewinder) Position() uint64 {
+	return r.pos
+}
+
+// Checkpoints returns the positions of the snapshots kept, oldest first.
+func (r *Rewinder) Checkpoints() []uint64 {
+	positions := make([]uint64, len(r.checkpoints))
+	for n, c := range r.checkpoints {
+		positions[n] = c.pos
+	}
+	return positions
+}
+
+func (r *Rewinder) Registers() machine.Registers {
+	return r.machine.Registers()
+}
+
+func (r *Rewinder) ReadMemory(addr uint32, buf []byte) error {
+	return r.machine.ReadMemory(addr, buf)
+}
+
+// SetRegisters changes the registers. What happened after the current
+// position is forgotten, as it won't happen again.
+func (r *Rewinder) SetRegisters(regs machine.Registers) {
+	r.machine.SetRegisters(regs)
+	// There's nowhere to report a failure to. If the machine can't be
+	// snapshotted, going back past here loses the change.
+	r.diverge()
+}
+
+// WriteMemory changes memory. What happened after the current position is
+// forgotten, as it won't happen again.
+func (r *Rewinder) WriteMemory(addr uint32, data []byte) error {
+	if err := r.machine.WriteMemory(addr, data); err != nil {
+		return err
+	}
+	return r.diverge()
+}
+
+// Step executes a single instruction.
+func (r *Rewinder) Step() error {
+	if err := r.machine.Step(); err != nil {
+		return err
+	}
+	r.pos++
+	if r.pos%r.interval == 0 && r.checkpoints[len(r.checkpoints)-1].pos < r.pos {
+		return r.checkpoint()
+	}
+	return nil
+}
+
+// StepBack goes back n instructions, or to position 0 if there aren't that
+// many.
+func (r *Rewinder) StepBack(n int) error {
+	if uint64(n) > r.pos {
+		return r.Seek(0)
+	}
+	return r.Seek(r.pos - uint64(n))
+}
+
+// Seek goes to position pos, running forward to it if it hasn't been reached
+// yet.
+func (r *Rewinder) Seek(pos uint64) error {
+	if pos < r.pos {
+		n := len(r.checkpoints) - 1
+		for r.checkpoints[n].pos > pos {
+			n--
+		}
+		if err := r.restore(n); err != nil {
+			return err
+		}
+	}
+	for r.pos < pos {
+		if err := r.Step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReverseToWrite goes back to the most recent instruction before the current
+// position which changed any of the watched memory, stopping before it is
+// executed. A write which stores the value already there isn't seen. If there
+// is no such instruction, ReverseToWrite returns ErrNoWrite and stays where it
+// is.
+func (r *Rewinder) ReverseToWrite(watches ...Watch) error {
+	end := r.pos
+	for n := len(r.checkpoints) - 1; n >= 0; n-- {
+		if r.checkpoints[n].pos >= end {
+			continue
+		}
+		stop := end
+		if n+1 < len(r.checkpoints) && r.checkpoints[n+1].pos < stop {
+			stop = r.checkpoints[n+1].pos
+		}
+		if err := r.restore(n); err != nil {
+			return err
+		}
+		found, last := false, uint64(0)
+		before := r.readWatched(watches)
+		for r.pos < stop {
+			if err := r.Step(); err != nil {
+				return err
+			}
+			after := r.readWatched(watches)
+			if after != before {
+				found, last = true, r.pos-1
+			}
+			before = after
+		}
+		if found {
+			return r.Seek(last)
+		}
+	}
+	if err := r.Seek(end); err != nil {
+		return err
+	}
+	return ErrNoWrite
+}
+
+// readWatched returns the contents of the watched memory. Memory which can't
+// be read reads as nothing, so that it counts as changed if it later can be.
+func (r *Rewinder) readWatched(watches []Watch) string {
+	all := []byte{}
+	for _, w := range watches {
+		buf := make([]byte, w.Length)
+		if r.machine.ReadMemory(w.Addr, buf) == nil {
+			all = append(all, buf...)
+		}
+	}
+	return string(all)
+}
+
+func (r *Rewinder) checkpoint() error {
+	snap, err := r.machine.Snapshot()
+	if err != nil {
+		return err
+	}
+	r.checkpoints = append(r.checkpoints, checkpoint{pos: r.pos, snap: snap})
+	r.thin()
+	return nil
+}
+
+// thin drops checkpoints until there are no more than MaxCheckpoints, always
+// keeping position 0 and the latest. Each time it drops the one which leaves
+// the smallest gap between its neighbours for how far back they are, so the
+// gaps end up growing in proportion to age.
+func (r *Rewinder) thin() {
+	for len(r.checkpoints) > MaxCheckpoints {
+		drop, least := 0, math.Inf(1)
+		for n := 1; n < len(r.checkpoints)-1; n++ {
+			gap := float64(r.checkpoints[n+1].pos - r.checkpoints[n-1].pos)
+			age := float64(r.pos - r.checkpoints[n-1].pos)
+			if gap/age < least {
+				drop, least = n, gap/age
+			}
+		}
+		r.checkpoints = append(r.checkpoints[:drop], r.checkpoints[drop+1:]...)
+	}
+}
+
+func (r *Rewinder) restore(n int) error {
+	if err := r.machine.Restore(r.checkpoints[n].snap); err != nil {
+		return err
+	}
+	r.pos = r.checkpoints[n].pos
+	return nil
+}
+
+// diverge replaces the checkpoints from the current position on with one
+// taken now, since running forward from an earlier one wouldn't include a
+// change just made.
+func (r *Rewinder) diverge() error {
+	snap, err := r.machine.Snapshot()
+	if err != nil {
+		return err
+	}
+	n := len(r.checkpoints)
+	for n > 0 && r.checkpoints[n-1].pos >= r.pos {
+		n--
+	}
+	r.checkpoints = append(r.checkpoints[:n], checkpoint{pos: r.pos, snap: snap})
+	r.thin()
+	return nil
+}
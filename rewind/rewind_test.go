@@ -0,0 +1,162 @@
+package rewind_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kn100/cybemu/machine"
+	"github.com/kn100/cybemu/rewind"
+	"github.com/kn100/cybemu/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+// counter is a machine which, on every step, increments er0 and pc and stores
+// the low byte of er0 at 0x10 + er0 % 4. Its memory is 0x20 bytes long.
+type counter struct {
+	regs      machine.Registers
+	memory    [0x20]byte
+	snapshots int
+}
+
+func (c *counter) Registers() machine.Registers        { return c.regs }
+func (c *counter) SetRegisters(regs machine.Registers) { c.regs = regs }
+
+func (c *counter) ReadMemory(addr uint32, buf []byte) error {
+	if int(addr)+len(buf) > len(c.memory) {
+		return errors.New("out of range")
+	}
+	copy(buf, c.memory[addr:])
+	return nil
+}
+
+func (c *counter) WriteMemory(addr uint32, data []byte) error {
+	copy(c.memory[addr:], data)
+	return nil
+}
+
+func (c *counter) Step() error {
+	if c.regs.ER[0] == 1000 {
+		return errors.New("halted")
+	}
+	c.regs.ER[0]++
+	c.regs.PC += 2
+	c.memory[0x10+c.regs.ER[0]%4] = byte(c.regs.ER[0])
+	return nil
+}
+
+func (c *counter) Snapshot() (*snapshot.Snapshot, error) {
+	c.snapshots++
+	return &snapshot.Snapshot{
+		Registers: c.regs,
+		Memory:    []snapshot.Region{{Data: append([]byte{}, c.memory[:]...)}},
+	}, nil
+}
+
+func (c *counter) Restore(s *snapshot.Snapshot) error {
+	c.regs = s.Registers
+	copy(c.memory[:], s.Memory[0].Data)
+	return nil
+}
+
+func TestStepBack(t *testing.T) {
+	c := &counter{}
+	r, err := rewind.New(c, 4)
+	assert.NoError(t, err)
+	for n := 0; n < 10; n++ {
+		assert.NoError(t, r.Step())
+	}
+	assert.Equal(t, uint64(10), r.Position())
+	assert.Equal(t, 3, c.snapshots, "at 0, 4 and 8")
+
+	assert.NoError(t, r.StepBack(3))
+	assert.Equal(t, uint64(7), r.Position())
+	assert.Equal(t, uint32(7), r.Registers().ER[0])
+	assert.Equal(t, uint32(14), r.Registers().PC)
+	buf := make([]byte, 4)
+	assert.NoError(t, r.ReadMemory(0x10, buf))
+	assert.Equal(t, []byte{4, 5, 6, 7}, buf)
+
+	assert.NoError(t, r.Seek(12))
+	assert.Equal(t, uint32(12), r.Registers().ER[0])
+	assert.Equal(t, 4, c.snapshots, "only 12 is new")
+	assert.NoError(t, r.StepBack(100))
+	assert.Equal(t, uint64(0), r.Position())
+	assert.Equal(t, uint32(0), r.Registers().ER[0])
+
+	_, err = rewind.New(c, 0)
+	assert.Error(t, err)
+}
+
+func TestReverseToWrite(t *testing.T) {
+	c := &counter{}
+	r, err := rewind.New(c, 5)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Seek(23))
+
+	// 0x11 is written by the steps taking er0 to 1, 5, 9, ..., 21, which
+	// execute at positions 0, 4, 8, ..., 20.
+	assert.NoError(t, r.ReverseToWrite(rewind.Watch{Addr: 0x11, Length: 1}))
+	assert.Equal(t, uint64(20), r.Position())
+	assert.NoError(t, r.ReverseToWrite(rewind.Watch{Addr: 0x11, Length: 1}))
+	assert.Equal(t, uint64(16), r.Position())
+	assert.NoError(t, r.ReverseToWrite(rewind.Watch{Addr: 0x11, Length: 1}, rewind.Watch{Addr: 0x12, Length: 1}))
+	assert.Equal(t, uint64(13), r.Position())
+
+	assert.NoError(t, r.Seek(3))
+	assert.NoError(t, r.ReverseToWrite(rewind.Watch{Addr: 0x11, Length: 1}))
+	assert.Equal(t, uint64(0), r.Position())
+	assert.Equal(t, rewind.ErrNoWrite, r.ReverseToWrite(rewind.Watch{Addr: 0x11, Length: 1}))
+	assert.NoError(t, r.Seek(9))
+	assert.Equal(t, rewind.ErrNoWrite, r.ReverseToWrite(rewind.Watch{Addr: 0x00, Length: 4}))
+	assert.Equal(t, uint64(9), r.Position(), "stays put when there is no write")
+}
+
+func TestChangesForgetTheFuture(t *testing.T) {
+	c := &counter{}
+	r, err := rewind.New(c, 4)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Seek(10))
+	assert.NoError(t, r.Seek(6))
+
+	regs := r.Registers()
+	regs.ER[0] = 100
+	r.SetRegisters(regs)
+	assert.NoError(t, r.Seek(10))
+	assert.Equal(t, uint32(104), r.Registers().ER[0])
+	assert.NoError(t, r.StepBack(2))
+	assert.Equal(t, uint32(102), r.Registers().ER[0])
+
+	assert.NoError(t, r.WriteMemory(0x00, []byte{0xAA}))
+	assert.NoError(t, r.Seek(9))
+	assert.NoError(t, r.StepBack(1))
+	buf := make([]byte, 1)
+	assert.NoError(t, r.ReadMemory(0x00, buf))
+	assert.Equal(t, []byte{0xAA}, buf)
+}
+
+func TestCheckpointsAreBounded(t *testing.T) {
+	c := &counter{}
+	r, err := rewind.New(c, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Seek(999))
+	assert.Equal(t, 1000, c.snapshots)
+
+	kept := r.Checkpoints()
+	assert.Len(t, kept, rewind.MaxCheckpoints)
+	assert.Equal(t, uint64(0), kept[0])
+	assert.Equal(t, uint64(999), kept[len(kept)-1])
+	// Gaps shrink towards the present, from 64 instructions at the start to
+	// one at the end.
+	for n := 2; n < len(kept); n++ {
+		assert.LessOrEqual(t, kept[n]-kept[n-1], kept[n-1]-kept[n-2], "gap before %d", kept[n])
+	}
+	assert.Equal(t, uint64(64), kept[1]-kept[0])
+	assert.Equal(t, uint64(1), kept[len(kept)-1]-kept[len(kept)-2])
+
+	assert.NoError(t, r.StepBack(900))
+	assert.Equal(t, uint32(99), r.Registers().ER[0])
+	buf := make([]byte, 4)
+	assert.NoError(t, r.ReadMemory(0x10, buf))
+	assert.Equal(t, []byte{96, 97, 98, 99}, buf)
+	assert.Len(t, r.Checkpoints(), rewind.MaxCheckpoints)
+}
@@ -0,0 +1,209 @@
+// contains a versioned file format for save states: the whole state of an
+// emulated machine at one instant, so that a run can be resumed from it rather
+// than from a cold boot.
+//
+// A snapshot file starts with the 4 byte magic "CYSS" and a big endian 16 bit
+// version. The rest of the file is a gzip stream holding, all big endian:
+//
+//	uint64   cycle count
+//	uint32   er0 to er7, then pc
+//	byte     ccr, then exr
+//	regions  memory
+//	regions  peripheral registers
+//	uint32   number of pending events, followed for each by a uint64 cycle it
+//	         is due, a uint16 kind, a uint32 length and that many bytes of data
+//
+// where regions is a uint32 count followed for each region by a uint32
+// address, a uint32 length and that many bytes.
+package snapshot
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kn100/cybemu/machine"
+)
+
+// Magic starts every snapshot file.
+const Magic = "CYSS"
+
+// Version is the version of the format written by Save. Load refuses any
+// other.
+const Version = 1
+
+// maxRegion bounds the length of a region or event read from a file, so a
+// corrupt length doesn't turn into a huge allocation. It comfortably exceeds
+// the 24 bit address space.
+const maxRegion = 1 << 24
+
+// Region is a run of bytes found at a bus address.
+type Region struct {
+	Addr uint32
+	Data []byte
+}
+
+// Event is something scheduled to happen at a future cycle, such as a timer
+// expiring. Kind and Data mean whatever the component that scheduled the
+// event needs them to.
+type Event struct {
+	Cycle uint64
+	Kind  uint16
+	Data  []byte
+}
+
+// Snapshot is the state of a machine.
+type Snapshot struct {
+	Cycle       uint64
+	Registers   machine.Registers
+	Memory      []Region
+	Peripherals []Region
+	Events      []Event
+}
+
+// Machine is anything which can be snapshotted and restored.
+type Machine interface {
+	Snapshot() (*Snapshot, error)
+	Restore(*Snapshot) error
+}
+
+// Save writes s to w.
+func (s *Snapshot) Save(w io.Writer) error {
+	header := make([]byte, len(Magic)+2)
+	copy(header, Magic)
+	binary.BigEndian.PutUint16(header[len(Magic):], Version)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(w)
+	bw := bufio.NewWriter(zw)
+	put := func(v interface{}) {
+		// bufio.Writer holds on to the first error, which Flush returns.
+		binary.Write(bw, binary.BigEndian, v)
+	}
+	put(s.Cycle)
+	put(s.Registers.ER)
+	put(s.Registers.PC)
+	put(s.Registers.CCR)
+	put(s.Registers.EXR)
+	for _, regions := range [][]Region{s.Memory, s.Peripherals} {
+		put(uint32(len(regions)))
+		for _, r := range regions {
+			put(r.Addr)
+			put(uint32(len(r.Data)))
+			bw.Write(r.Data)
+		}
+	}
+	put(uint32(len(s.Events)))
+	for _, e := range s.Events {
+		put(e.Cycle)
+		put(e.Kind)
+		put(uint32(len(e.Data)))
+		bw.Write(e.Data)
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// Load reads a snapshot from r.
+func Load(r io.Reader) (*Snapshot, error) {
+	header := make([]byte, len(Magic)+2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("couldn't read snapshot header: %w", err)
+	}
+	if string(header[:len(Magic)]) != Magic {
+		return nil, errors.New("not a snapshot")
+	}
+	if v := binary.BigEndian.Uint16(header[len(Magic):]); v != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %d", v)
+	}
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(zr)
+	var readErr error
+	get := func(v interface{}) {
+		if readErr == nil {
+			readErr = binary.Read(br, binary.BigEndian, v)
+		}
+	}
+	getBytes := func() []byte {
+		var length uint32
+		get(&length)
+		if readErr != nil {
+			return nil
+		}
+		if length > maxRegion {
+			readErr = fmt.Errorf("region of %d bytes is too large", length)
+			return nil
+		}
+		data := make([]byte, length)
+		_, readErr = io.ReadFull(br, data)
+		return data
+	}
+
+	s := &Snapshot{}
+	get(&s.Cycle)
+	get(&s.Registers.ER)
+	get(&s.Registers.PC)
+	get(&s.Registers.CCR)
+	get(&s.Registers.EXR)
+	for _, regions := range []*[]Region{&s.Memory, &s.Peripherals} {
+		var count uint32
+		get(&count)
+		for i := uint32(0); i < count && readErr == nil; i++ {
+			r := Region{}
+			get(&r.Addr)
+			r.Data = getBytes()
+			*regions = append(*regions, r)
+		}
+	}
+	var count uint32
+	get(&count)
+	for i := uint32(0); i < count && readErr == nil; i++ {
+		e := Event{}
+		get(&e.Cycle)
+		get(&e.Kind)
+		e.Data = getBytes()
+		s.Events = append(s.Events, e)
+	}
+	if readErr == io.EOF {
+		readErr = io.ErrUnexpectedEOF
+	}
+	if readErr != nil {
+		return nil, fmt.Errorf("corrupt snapshot: %w", readErr)
+	}
+	return s, nil
+}
+
+// SaveFile writes s to the file at path, replacing it if it exists.
+func SaveFile(path string, s *Snapshot) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := s.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadFile reads a snapshot from the file at path.
+func LoadFile(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
@@ -0,0 +1,75 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/kn100/cybemu/machine"
+	"github.com/kn100/cybemu/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSnapshot() *snapshot.Snapshot {
+	ram := make([]byte, 0x10000)
+	for i := range ram {
+		ram[i] = byte(i * 7)
+	}
+	return &snapshot.Snapshot{
+		Cycle: 123456789,
+		Registers: machine.Registers{
+			ER:  [8]uint32{1, 2, 3, 4, 5, 6, 7, 0xFFFEFC},
+			PC:  0x1234,
+			CCR: 0x84,
+			EXR: 0x07,
+		},
+		Memory: []snapshot.Region{
+			{Addr: 0x200000, Data: ram},
+			{Addr: 0xFFEC00, Data: []byte{0xDE, 0xAD}},
+		},
+		Peripherals: []snapshot.Region{{Addr: 0xFFFE80, Data: []byte{0x01, 0x02, 0x03}}},
+		Events: []snapshot.Event{
+			{Cycle: 123456800, Kind: 1, Data: []byte{0x10}},
+			{Cycle: 123999999, Kind: 2, Data: []byte{}},
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, testSnapshot().Save(&buf))
+	assert.Equal(t, []byte("CYSS\x00\x01"), buf.Bytes()[:6])
+	assert.Less(t, buf.Len(), 0x10000, "memory should be compressed")
+
+	s, err := snapshot.Load(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, testSnapshot(), s)
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "boot.cyss")
+	assert.NoError(t, snapshot.SaveFile(path, testSnapshot()))
+	s, err := snapshot.LoadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, testSnapshot(), s)
+
+	_, err = snapshot.LoadFile(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestLoadErrors(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, testSnapshot().Save(&buf))
+	good := buf.Bytes()
+
+	_, err := snapshot.Load(bytes.NewReader([]byte("CY")))
+	assert.Error(t, err)
+	_, err = snapshot.Load(bytes.NewReader([]byte("SNAP\x00\x01")))
+	assert.EqualError(t, err, "not a snapshot")
+	_, err = snapshot.Load(bytes.NewReader([]byte("CYSS\x00\x02")))
+	assert.EqualError(t, err, "unsupported snapshot version 2")
+
+	// Cut the gzip stream short.
+	_, err = snapshot.Load(bytes.NewReader(good[:len(good)/2]))
+	assert.Error(t, err)
+}
@@ -0,0 +1,186 @@
+package trace
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Magic starts every binary trace.
+const Magic = "CYTR"
+
+// Version is the version of the binary format written by BinaryWriter.
+const Version = 1
+
+// BinaryWriter writes records in the binary format.
+type BinaryWriter struct {
+	w   *bufio.Writer
+	buf []byte
+}
+
+// NewBinaryWriter returns a BinaryWriter writing to w, having written the
+// header. Flush must be called once the last record is written.
+func NewBinaryWriter(w io.Writer) (*BinaryWriter, error) {
+	bw := &BinaryWriter{w: bufio.NewWriter(w)}
+	if _, err := bw.w.WriteString(Magic); err != nil {
+		return nil, err
+	}
+	if err := bw.w.WriteByte(Version); err != nil {
+		return nil, err
+	}
+	return bw, nil
+}
+
+// Write writes a single record.
+func (b *BinaryWriter) Write(r Record) error {
+	if len(r.Bytes) > 0xFF || len(r.Regs) > 0xFF || len(r.Accesses) > 0xFF {
+		return errors.New("record has too many bytes, registers or accesses to encode")
+	}
+	buf := b.buf[:0]
+	buf = appendUvarint(buf, uint64(r.PC))
+	buf = append(buf, byte(len(r.Bytes)))
+	buf = append(buf, r.Bytes...)
+	buf = append(buf, byte(len(r.Regs)))
+	for _, d := range r.Regs {
+		buf = append(buf, byte(d.Reg))
+		buf = appendUvarint(buf, uint64(d.Value))
+	}
+	buf = append(buf, byte(len(r.Accesses)))
+	for _, a := range r.Accesses {
+		flags := byte(a.Size)
+		if a.Write {
+			flags |= 0x80
+		}
+		buf = append(buf, flags)
+		buf = appendUvarint(buf, uint64(a.Addr))
+		buf = appendUvarint(buf, uint64(a.Value))
+	}
+	b.buf = buf
+	_, err := b.w.Write(buf)
+	return err
+}
+
+// Flush writes any buffered records to the underlying io.Writer.
+func (b *BinaryWriter) Flush() error {
+	return b.w.Flush()
+}
+
+// Reader reads records in the binary format.
+type Reader struct {
+	r *bufio.Reader
+}
+
+// NewReader returns a Reader for r, having checked the header.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(Magic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("couldn't read trace header: %w", err)
+	}
+	if string(header[:len(Magic)]) != Magic {
+		return nil, errors.New("not a binary trace")
+	}
+	if header[len(Magic)] != Version {
+		return nil, fmt.Errorf("unsupported trace version %d", header[len(Magic)])
+	}
+	return &Reader{r: br}, nil
+}
+
+// Read returns the next record. It returns io.EOF once there are no more, and
+// io.ErrUnexpectedEOF if the trace ends part way through a record. Records
+// ParseText would refuse, such as those with an unknown register or access
+// size, or with a value too big for 32 bits, are refused here too.
+func (t *Reader) Read() (Record, error) {
+	pc, err := binary.ReadUvarint(t.r)
+	if err != nil {
+		return Record{}, err
+	}
+	if pc > math.MaxUint32 {
+		return Record{}, fmt.Errorf("bad address %#x", pc)
+	}
+	r := Record{PC: uint32(pc)}
+
+	n, err := t.r.ReadByte()
+	if err != nil {
+		return Record{}, unexpected(err)
+	}
+	r.Bytes = make([]byte, n)
+	if _, err := io.ReadFull(t.r, r.Bytes); err != nil {
+		return Record{}, unexpected(err)
+	}
+	r.Text = Disassemble(r.PC, r.Bytes)
+
+	if n, err = t.r.ReadByte(); err != nil {
+		return Record{}, unexpected(err)
+	}
+	for i := 0; i < int(n); i++ {
+		reg, err := t.r.ReadByte()
+		if err != nil {
+			return Record{}, unexpected(err)
+		}
+		if !validReg(int(reg)) {
+			return Record{}, fmt.Errorf("unknown register %d", reg)
+		}
+		v, err := binary.ReadUvarint(t.r)
+		if err != nil {
+			return Record{}, unexpected(err)
+		}
+		if v > math.MaxUint32 {
+			return Record{}, fmt.Errorf("bad register value %#x", v)
+		}
+		r.Regs = append(r.Regs, RegDelta{Reg: int(reg), Value: uint32(v)})
+	}
+
+	if n, err = t.r.ReadByte(); err != nil {
+		return Record{}, unexpected(err)
+	}
+	for i := 0; i < int(n); i++ {
+		flags, err := t.r.ReadByte()
+		if err != nil {
+			return Record{}, unexpected(err)
+		}
+		if !validSize(int(flags & 0x7F)) {
+			return Record{}, fmt.Errorf("bad memory access size %d", flags&0x7F)
+		}
+		addr, err := binary.ReadUvarint(t.r)
+		if err != nil {
+			return Record{}, unexpected(err)
+		}
+		if addr > math.MaxUint32 {
+			return Record{}, fmt.Errorf("bad memory address %#x", addr)
+		}
+		v, err := binary.ReadUvarint(t.r)
+		if err != nil {
+			return Record{}, unexpected(err)
+		}
+		if v > math.MaxUint32 {
+			return Record{}, fmt.Errorf("bad memory value %#x", v)
+		}
+		r.Accesses = append(r.Accesses, Access{
+			Write: flags&0x80 != 0,
+			Size:  int(flags & 0x7F),
+			Addr:  uint32(addr),
+			Value: uint32(v),
+		})
+	}
+	return r, nil
+}
+
+// appendUvarint appends v to buf as a uvarint.
+func appendUvarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
+
+// unexpected turns io.EOF part way through a record into
+// io.ErrUnexpectedEOF.
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
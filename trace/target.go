@@ -0,0 +1,173 @@
+package trace
+
+import (
+	"github.com/kn100/cybemu/disassembler"
+	"github.com/kn100/cybemu/instruction"
+	"github.com/kn100/cybemu/machine"
+	"github.com/kn100/cybemu/opcode"
+	"github.com/kn100/cybemu/size"
+)
+
+// Writer is where a Target writes its records. TextWriter and BinaryWriter
+// are both Writers.
+type Writer interface {
+	Write(Record) error
+}
+
+// Target is a machine.Target which records a trace of the instructions it
+// steps.
+type Target struct {
+	machine.Target
+	w Writer
+}
+
+// NewTarget returns a Target which steps target, writing a record of each
+// instruction executed to w.
+func NewTarget(target machine.Target, w Writer) *Target {
+	return &Target{Target: target, w: w}
+}
+
+// Step executes a single instruction, and writes a record of it once it
+// completes. The memory accessed is worked out from the instruction's
+// operands and the registers it started with, as AccessSize describes, so
+// return addresses and ccr pushed and popped by calls, returns and traps
+// aren't recorded. Neither are the bytes eepmov copies, as there can be more
+// of them than a record holds. If the instruction at PC can't be read, Step
+// fails without executing it.
+func (t *Target) Step() error {
+	before := t.Registers()
+	inst, err := disassembler.DecodeAt(t.Target, before.PC)
+	if err != nil {
+		return err
+	}
+	accesses := memoryAccesses(&inst, before)
+	for n := range accesses {
+		if !accesses[n].Write {
+			accesses[n].Value = t.read(accesses[n])
+		}
+	}
+	if err := t.Target.Step(); err != nil {
+		return err
+	}
+	for n := range accesses {
+		if accesses[n].Write {
+			accesses[n].Value = t.read(accesses[n])
+		}
+	}
+	r := Record{
+		PC:    before.PC,
+		Bytes: inst.Bytes,
+		Text:  inst.String(),
+		Regs:  Deltas(before, t.Registers()),
+	}
+	if len(accesses) > 0 {
+		r.Accesses = accesses
+	}
+	if len(r.Regs) == 0 {
+		r.Regs = nil
+	}
+	return t.w.Write(r)
+}
+
+// read returns the value found where a was made. Memory which can't be read
+// reads as zero.
+func (t *Target) read(a Access) uint32 {
+	buf := make([]byte, a.Size)
+	if t.ReadMemory(a.Addr, buf) != nil {
+		return 0
+	}
+	v := uint32(0)
+	for _, b := range buf {
+		v = v<<8 | uint32(b)
+	}
+	return v
+}
+
+// memoryAccesses returns the memory inst will access when run with regs, in
+// the order it does so, without their values.
+func memoryAccesses(inst *instruction.Inst, regs machine.Registers) []Access {
+	n := accessBytes(inst.AccessSize())
+	if n == 0 {
+		return nil
+	}
+	sp := regs.ER[7]
+	accesses := []Access{}
+	add := func(write bool, size int, addr uint32) {
+		accesses = append(accesses, Access{Write: write, Size: size, Addr: addr & 0xFFFFFF})
+	}
+	switch inst.Opcode {
+	case opcode.Push:
+		add(true, n, sp-uint32(n))
+	case opcode.Pop:
+		add(false, n, sp)
+	case opcode.Ldm, opcode.Stm:
+		for _, op := range inst.Operands() {
+			l, ok := op.RegisterList()
+			if !ok {
+				continue
+			}
+			for i := 0; i < int(l.Count); i++ {
+				if inst.Opcode == opcode.Ldm {
+					add(false, 4, sp+uint32(4*i))
+				} else {
+					add(true, 4, sp-uint32(4*(i+1)))
+				}
+			}
+		}
+	case opcode.Eepmov:
+	default:
+		var writes []Access
+		for i, op := range inst.Operands() {
+			addr, ok := effectiveAddress(op, regs, n)
+			if !ok {
+				continue
+			}
+			read, written := inst.Accesses(i)
+			if read {
+				add(false, n, addr)
+			}
+			if written {
+				writes = append(writes, Access{Write: true, Size: n, Addr: addr & 0xFFFFFF})
+			}
+		}
+		accesses = append(accesses, writes...)
+	}
+	return accesses
+}
+
+// effectiveAddress returns the address of the memory op refers to when an
+// access of n bytes is made with regs, or false if it isn't in memory.
+func effectiveAddress(op instruction.Operand, regs machine.Registers, n int) (uint32, bool) {
+	if a, ok := op.Absolute(); ok {
+		return a.EffectiveAddress(), true
+	}
+	if i, ok := op.Indirect(); ok {
+		return regs.ER[i.Register], true
+	}
+	if d, ok := op.Displacement(); ok {
+		return regs.ER[d.Register] + uint32(d.Displacement), true
+	}
+	if p, ok := op.PostInc(); ok {
+		return regs.ER[p.Register], true
+	}
+	if p, ok := op.PreDec(); ok {
+		return regs.ER[p.Register] - uint32(n), true
+	}
+	if m, ok := op.MemoryIndirect(); ok {
+		return uint32(m.Address), true
+	}
+	return 0, false
+}
+
+// accessBytes returns the number of bytes in an access of size s.
+func accessBytes(s size.Size) int {
+	switch s {
+	case size.Byte:
+		return 1
+	case size.Word:
+		return 2
+	case size.Longword:
+		return 4
+	}
+	return 0
+}
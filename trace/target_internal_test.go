@@ -0,0 +1,36 @@
+package trace
+
+import (
+	"testing"
+
+	"github.com/kn100/cybemu/disassembler"
+	"github.com/kn100/cybemu/machine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryAccesses(t *testing.T) {
+	regs := machine.Registers{ER: [8]uint32{1: 0x2000, 7: 0x1000}}
+	for _, tc := range []struct {
+		code []byte
+		want []Access
+	}{
+		{[]byte{0x8A, 0x80}, nil},
+		{[]byte{0x6D, 0xF3}, []Access{{Write: true, Size: 2, Addr: 0xFFE}}},
+		{[]byte{0x01, 0x00, 0x6D, 0x73}, []Access{{Size: 4, Addr: 0x1000}}},
+		{[]byte{0x01, 0x10, 0x6D, 0xF2}, []Access{{Write: true, Size: 4, Addr: 0xFFC}, {Write: true, Size: 4, Addr: 0xFF8}}},
+		{[]byte{0x01, 0x10, 0x6D, 0x72}, []Access{{Size: 4, Addr: 0x1000}, {Size: 4, Addr: 0x1004}}},
+		{[]byte{0x6F, 0x10, 0xFF, 0xF0}, []Access{{Size: 2, Addr: 0x1FF0}}},
+		{[]byte{0x6C, 0x98}, []Access{{Write: true, Size: 1, Addr: 0x1FFF}}},
+		{[]byte{0x7D, 0x10, 0x70, 0x00}, []Access{{Size: 1, Addr: 0x2000}, {Write: true, Size: 1, Addr: 0x2000}}},
+		{[]byte{0x31, 0x40}, []Access{{Write: true, Size: 1, Addr: 0xFFFF40}}},
+		{[]byte{0x5B, 0x20}, []Access{{Size: 4, Addr: 0x20}}},
+		{[]byte{0x7B, 0x5C, 0x59, 0x8F}, nil},
+	} {
+		inst := disassembler.DisassembleAt(tc.code, 0x100)[0]
+		got := memoryAccesses(&inst, regs)
+		if len(got) == 0 {
+			got = nil
+		}
+		assert.Equal(t, tc.want, got, inst.String())
+	}
+}
@@ -0,0 +1,129 @@
+package trace
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TextWriter writes records in the text format.
+type TextWriter struct {
+	w *bufio.Writer
+}
+
+// NewTextWriter returns a TextWriter writing to w. Flush must be called once
+// the last record is written.
+func NewTextWriter(w io.Writer) *TextWriter {
+	return &TextWriter{w: bufio.NewWriter(w)}
+}
+
+// Write writes a single record.
+func (t *TextWriter) Write(r Record) error {
+	_, err := fmt.Fprintln(t.w, r.Format())
+	return err
+}
+
+// Flush writes any buffered records to the underlying io.Writer.
+func (t *TextWriter) Flush() error {
+	return t.w.Flush()
+}
+
+// ParseText parses a single line of the text format.
+func ParseText(line string) (Record, error) {
+	fields := strings.Split(strings.TrimRight(line, "\r\n"), " | ")
+	if len(fields) != 4 {
+		return Record{}, fmt.Errorf("expected 4 fields, got %d in %q", len(fields), line)
+	}
+	r := Record{Text: fields[1]}
+
+	head := strings.Fields(fields[0])
+	if len(head) != 2 {
+		return Record{}, fmt.Errorf("expected address and bytes in %q", fields[0])
+	}
+	pc, err := strconv.ParseUint(head[0], 16, 32)
+	if err != nil {
+		return Record{}, fmt.Errorf("bad address %q", head[0])
+	}
+	r.PC = uint32(pc)
+	if r.Bytes, err = hex.DecodeString(head[1]); err != nil {
+		return Record{}, fmt.Errorf("bad bytes %q", head[1])
+	}
+
+	if fields[2] != "-" {
+		for _, d := range strings.Fields(fields[2]) {
+			delta, err := parseRegDelta(d)
+			if err != nil {
+				return Record{}, err
+			}
+			r.Regs = append(r.Regs, delta)
+		}
+	}
+	if fields[3] != "-" {
+		accesses := strings.Fields(fields[3])
+		if len(accesses)%2 != 0 {
+			return Record{}, fmt.Errorf("bad memory accesses %q", fields[3])
+		}
+		for n := 0; n < len(accesses); n += 2 {
+			a, err := parseAccess(accesses[n], accesses[n+1])
+			if err != nil {
+				return Record{}, err
+			}
+			r.Accesses = append(r.Accesses, a)
+		}
+	}
+	return r, nil
+}
+
+func parseRegDelta(s string) (RegDelta, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return RegDelta{}, fmt.Errorf("bad register %q", s)
+	}
+	reg := -1
+	switch parts[0] {
+	case "ccr":
+		reg = RegCCR
+	case "exr":
+		reg = RegEXR
+	default:
+		if n, err := strconv.Atoi(strings.TrimPrefix(parts[0], "er")); err == nil && strings.HasPrefix(parts[0], "er") && n >= 0 && n < 8 {
+			reg = n
+		}
+	}
+	if reg < 0 {
+		return RegDelta{}, fmt.Errorf("unknown register %q", parts[0])
+	}
+	v, err := strconv.ParseUint(parts[1], 16, 32)
+	if err != nil {
+		return RegDelta{}, fmt.Errorf("bad register value %q", s)
+	}
+	return RegDelta{Reg: reg, Value: uint32(v)}, nil
+}
+
+// parseAccess parses an access written as "w2" followed by "00fffefe=1234".
+func parseAccess(kind string, s string) (Access, error) {
+	if len(kind) != 2 || (kind[0] != 'r' && kind[0] != 'w') {
+		return Access{}, fmt.Errorf("bad memory access %q", kind)
+	}
+	a := Access{Write: kind[0] == 'w', Size: int(kind[1] - '0')}
+	if !validSize(a.Size) {
+		return Access{}, fmt.Errorf("bad memory access size %q", kind)
+	}
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return Access{}, fmt.Errorf("bad memory access %q", s)
+	}
+	addr, err := strconv.ParseUint(parts[0], 16, 32)
+	if err != nil {
+		return Access{}, fmt.Errorf("bad memory address %q", parts[0])
+	}
+	v, err := strconv.ParseUint(parts[1], 16, 32)
+	if err != nil {
+		return Access{}, fmt.Errorf("bad memory value %q", parts[1])
+	}
+	a.Addr, a.Value = uint32(addr), uint32(v)
+	return a, nil
+}
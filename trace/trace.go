@@ -0,0 +1,174 @@
+// contains writers and readers for execution traces: one record per executed
+// instruction, holding its address, raw bytes and assembly notation, the
+// registers it changed, and the memory it touched. Traces are meant to be
+// diffed against captures from real hardware and other emulators, so both
+// formats below are deterministic: the same execution always produces the same
+// bytes. A Target records a trace of the machine it steps.
+//
+// The text format is one line per record, with four fields separated by " | ":
+//
+//	000100 0d13 | mov.w r1, r3 | er3=00001234 ccr=00 | -
+//	000102 3140 | mov.b r1h, @0x40:8 | ccr=08 | w1 00ffff40=12
+//
+// The fields are the address (6 hex digits), the raw bytes (2 hex digits each,
+// no separators), the assembly notation, the registers changed, and the memory
+// accessed. An empty field is written as "-". Changed registers are written as
+// name=value with the value as 8 hex digits for er0-er7 and 2 for ccr and exr,
+// in the order er0-er7, ccr, exr. PC is never listed, as it is the address on
+// the next line. Memory accesses are written in the order they happened, as r
+// or w, the access size in bytes, the address as 8 hex digits, "=", and the
+// value as 2, 4 or 8 hex digits.
+//
+// The binary format starts with the 4 byte magic "CYTR" and a version byte
+// (currently 1), followed by the records, each of which is:
+//
+//	uvarint  address
+//	byte     number of raw bytes, followed by the raw bytes
+//	byte     number of changed registers, followed for each by a byte
+//	         holding the register number (0-7 for er0-er7, 8 for ccr, 9 for
+//	         exr) and a uvarint holding its new value
+//	byte     number of memory accesses, followed for each by a byte holding
+//	         the size in the low 7 bits and 0x80 if it was a write, a uvarint
+//	         holding the address and a uvarint holding the value
+//
+// The assembly notation isn't stored in the binary format; Reader recreates
+// it by decoding the raw bytes.
+package trace
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kn100/cybemu/disassembler"
+	"github.com/kn100/cybemu/machine"
+)
+
+// Register numbers used in RegDelta.
+const (
+	RegCCR = 8
+	RegEXR = 9
+)
+
+// validReg returns true if reg is one of er0-er7, ccr or exr.
+func validReg(reg int) bool {
+	return reg >= 0 && reg <= RegEXR
+}
+
+// validSize returns true if size is a size a memory access can have.
+func validSize(size int) bool {
+	return size == 1 || size == 2 || size == 4
+}
+
+// RegDelta is the new value of a register changed by an instruction.
+type RegDelta struct {
+	Reg   int
+	Value uint32
+}
+
+// Access is a single memory access made by an instruction.
+type Access struct {
+	Write bool
+	// Size is 1, 2 or 4 bytes.
+	Size  int
+	Addr  uint32
+	Value uint32
+}
+
+// Record is a single executed instruction.
+type Record struct {
+	PC    uint32
+	Bytes []byte
+	// Text is the instruction in assembly notation, as returned by
+	// instruction.Inst.String.
+	Text     string
+	Regs     []RegDelta
+	Accesses []Access
+}
+
+// Deltas returns the registers which differ between before and after, in the
+// order they are written in a trace.
+func Deltas(before machine.Registers, after machine.Registers) []RegDelta {
+	deltas := []RegDelta{}
+	for n := range before.ER {
+		if before.ER[n] != after.ER[n] {
+			deltas = append(deltas, RegDelta{Reg: n, Value: after.ER[n]})
+		}
+	}
+	if before.CCR != after.CCR {
+		deltas = append(deltas, RegDelta{Reg: RegCCR, Value: uint32(after.CCR)})
+	}
+	if before.EXR != after.EXR {
+		deltas = append(deltas, RegDelta{Reg: RegEXR, Value: uint32(after.EXR)})
+	}
+	return deltas
+}
+
+// Disassemble returns the assembly notation for an instruction found at pc.
+func Disassemble(pc uint32, raw []byte) string {
+	inst, _ := disassembler.DecodeAt(rawMemory(raw), pc)
+	return inst.String()
+}
+
+// rawMemory is the bytes of a single instruction, as recorded in a trace.
+// Reading it gives them whatever address is asked for, padded with zeroes,
+// as Decode may look further ahead than the instruction itself.
+type rawMemory []byte
+
+func (r rawMemory) ReadMemory(addr uint32, buf []byte) error {
+	for n := range buf {
+		buf[n] = 0
+	}
+	copy(buf, r)
+	return nil
+}
+
+func regName(reg int) string {
+	switch reg {
+	case RegCCR:
+		return "ccr"
+	case RegEXR:
+		return "exr"
+	}
+	return fmt.Sprintf("er%d", reg)
+}
+
+// Format returns the record as a line of the text format, without a trailing
+// newline.
+func (r Record) Format() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%06x ", r.PC)
+	for _, raw := range r.Bytes {
+		fmt.Fprintf(&b, "%02x", raw)
+	}
+	fmt.Fprintf(&b, " | %s | ", r.Text)
+
+	if len(r.Regs) == 0 {
+		b.WriteString("-")
+	}
+	for n, d := range r.Regs {
+		if n > 0 {
+			b.WriteString(" ")
+		}
+		if d.Reg == RegCCR || d.Reg == RegEXR {
+			fmt.Fprintf(&b, "%s=%02x", regName(d.Reg), d.Value)
+		} else {
+			fmt.Fprintf(&b, "%s=%08x", regName(d.Reg), d.Value)
+		}
+	}
+	b.WriteString(" | ")
+
+	if len(r.Accesses) == 0 {
+		b.WriteString("-")
+	}
+	for n, a := range r.Accesses {
+		if n > 0 {
+			b.WriteString(" ")
+		}
+		rw := "r"
+		if a.Write {
+			rw = "w"
+		}
+		fmt.Fprintf(&b, "%s%d %08x=%0*x", rw, a.Size, a.Addr, a.Size*2, a.Value)
+	}
+	return b.String()
+}
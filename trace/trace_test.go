@@ -0,0 +1,182 @@
+package trace_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kn100/cybemu/cybemutest"
+	"github.com/kn100/cybemu/machine"
+	"github.com/kn100/cybemu/trace"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRecords() []trace.Record {
+	return []trace.Record{
+		{
+			PC:    0x100,
+			Bytes: []byte{0x0D, 0x13},
+			Text:  "mov.w r1, r3",
+			Regs:  []trace.RegDelta{{Reg: 3, Value: 0x1234}, {Reg: trace.RegCCR, Value: 0x00}},
+		},
+		{
+			PC:       0x102,
+			Bytes:    []byte{0x31, 0x40},
+			Text:     "mov.b r1h, @0x40:8",
+			Regs:     []trace.RegDelta{{Reg: trace.RegCCR, Value: 0x08}},
+			Accesses: []trace.Access{{Write: true, Size: 1, Addr: 0xFFFF40, Value: 0x12}},
+		},
+		{
+			PC:    0x104,
+			Bytes: []byte{0x7A, 0x00, 0x00, 0xFF, 0xEE, 0x00},
+			Text:  "mov.l #0x00FFEE00, er0",
+			Regs:  []trace.RegDelta{{Reg: 0, Value: 0xFFEE00}},
+			Accesses: []trace.Access{
+				{Size: 1, Addr: 0xFFEE00, Value: 0x12},
+				{Size: 4, Addr: 0xFFEE04, Value: 0xCAFEF00D},
+			},
+		},
+	}
+}
+
+const testText = `000100 0d13 | mov.w r1, r3 | er3=00001234 ccr=00 | -
+000102 3140 | mov.b r1h, @0x40:8 | ccr=08 | w1 00ffff40=12
+000104 7a0000ffee00 | mov.l #0x00FFEE00, er0 | er0=00ffee00 | r1 00ffee00=12 r4 00ffee04=cafef00d
+`
+
+func TestTextFormat(t *testing.T) {
+	records := testRecords()
+
+	var out bytes.Buffer
+	w := trace.NewTextWriter(&out)
+	for _, r := range records {
+		assert.NoError(t, w.Write(r))
+	}
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, testText, out.String())
+
+	for n, line := range strings.Split(strings.TrimSuffix(testText, "\n"), "\n") {
+		r, err := trace.ParseText(line)
+		assert.NoError(t, err)
+		assert.Equal(t, records[n], r)
+	}
+}
+
+func TestParseTextErrors(t *testing.T) {
+	for _, line := range []string{
+		"000100 0d13 | mov.w r1, r3 | -",
+		"zz 0d13 | mov.w r1, r3 | - | -",
+		"000100 0d1 | mov.w r1, r3 | - | -",
+		"000100 0d13 | mov.w r1, r3 | er8=00000000 | -",
+		"000100 0d13 | mov.w r1, r3 | - | w3 00000000=00",
+		"000100 0d13 | mov.w r1, r3 | - | w2",
+	} {
+		_, err := trace.ParseText(line)
+		assert.Error(t, err, line)
+	}
+}
+
+func TestBinaryFormat(t *testing.T) {
+	var out bytes.Buffer
+	w, err := trace.NewBinaryWriter(&out)
+	assert.NoError(t, err)
+	for _, r := range testRecords() {
+		assert.NoError(t, w.Write(r))
+	}
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, []byte("CYTR\x01\x80\x02\x02\x0d\x13\x02\x03\xb4\x24\x08\x00\x00"), out.Bytes()[:17])
+
+	encoded := out.Bytes()
+	r, err := trace.NewReader(bytes.NewReader(encoded))
+	assert.NoError(t, err)
+	for _, want := range testRecords() {
+		got, err := r.Read()
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+
+	r, err = trace.NewReader(bytes.NewReader(encoded[:len(encoded)-1]))
+	assert.NoError(t, err)
+	r.Read()
+	r.Read()
+	_, err = r.Read()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	for _, tc := range []struct {
+		record string
+		err    string
+	}{
+		{"\x80\x02\x00\x01\x0a\x00\x00", "unknown register 10"},
+		{"\x80\x02\x00\x01\x00\x80\x80\x80\x80\x10\x00", "bad register value 0x100000000"},
+		{"\x80\x02\x00\x00\x01\x83\x00\x00", "bad memory access size 3"},
+		{"\x80\x02\x00\x00\x01\x01\x80\x80\x80\x80\x10\x00", "bad memory address 0x100000000"},
+		{"\x80\x80\x80\x80\x10\x00\x00\x00", "bad address 0x100000000"},
+	} {
+		r, err = trace.NewReader(strings.NewReader("CYTR\x01" + tc.record))
+		assert.NoError(t, err)
+		_, err = r.Read()
+		assert.EqualError(t, err, tc.err)
+	}
+
+	_, err = trace.NewReader(strings.NewReader("CYTR\x02"))
+	assert.EqualError(t, err, "unsupported trace version 2")
+	_, err = trace.NewReader(strings.NewReader("TRACE"))
+	assert.EqualError(t, err, "not a binary trace")
+}
+
+func TestDeltas(t *testing.T) {
+	before := machine.Registers{CCR: 0x80}
+	after := before
+	after.ER[7] = 0xFFFEFC
+	after.ER[2] = 1
+	after.EXR = 0x07
+	assert.Equal(t, []trace.RegDelta{
+		{Reg: 2, Value: 1},
+		{Reg: 7, Value: 0xFFFEFC},
+		{Reg: trace.RegEXR, Value: 0x07},
+	}, trace.Deltas(before, after))
+	assert.Empty(t, trace.Deltas(before, before))
+}
+
+// topPage is the fake machine with the last 256 bytes of memory read from
+// its first 256, which is where it writes them.
+type topPage struct {
+	*cybemutest.Machine
+}
+
+func (t topPage) ReadMemory(addr uint32, buf []byte) error {
+	if addr >= 0xFFFF00 {
+		addr &= 0xFF
+	}
+	return t.Machine.ReadMemory(addr, buf)
+}
+
+func TestTarget(t *testing.T) {
+	m := cybemutest.NewProgram([]byte{
+		0x8A, 0x80, // 100: add.b #0x80, r2l
+		0x31, 0x40, // 102: mov.b r1h, @0x40:8
+		0x55, 0x02, // 104: bsr 0x108
+		0xFF, 0xFF, // 106: (invalid)
+		0x54, 0x70, // 108: rts
+	})
+	m.Regs.ER[1] = 0x1234
+	m.Regs.ER[2] = 0x80
+
+	var out bytes.Buffer
+	w := trace.NewTextWriter(&out)
+	target := trace.NewTarget(topPage{m}, w)
+	for n := 0; n < 4; n++ {
+		assert.NoError(t, target.Step())
+	}
+	assert.EqualError(t, target.Step(), "invalid instruction")
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, ""+
+		"000100 8a80 | add.b #0x80, r2l | er2=00000000 ccr=07 | -\n"+
+		"000102 3140 | mov.b r1h, @0x40:8 | - | w1 00ffff40=12\n"+
+		"000104 5502 | bsr 0x00000108:8 | er7=000001ec | -\n"+
+		"000108 5470 | rts | er7=000001f0 | -\n",
+		out.String())
+}
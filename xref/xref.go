@@ -0,0 +1,150 @@
+// contains a cross reference index over disassembled code, which records for
+// every address the instructions that jump to, call, read or write it.
+package xref
+
+import (
+	"sort"
+
+	"github.com/kn100/cybemu/instruction"
+)
+
+// Kind is the way an instruction refers to an address.
+type Kind int
+
+const (
+	Jump Kind = iota
+	Call
+	Read
+	Write
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Jump:
+		return "jump"
+	case Call:
+		return "call"
+	case Read:
+		return "read"
+	case Write:
+		return "write"
+	}
+	return "unknown"
+}
+
+// Ref is a reference from the instruction at From to the address To.
+type Ref struct {
+	From uint32
+	To   uint32
+	Kind Kind
+}
+
+// Index holds the references between addresses, and can be searched by
+// either end.
+type Index struct {
+	to   map[uint32][]Ref
+	from map[uint32][]Ref
+}
+
+// New returns an empty Index.
+func New() *Index {
+	return &Index{to: map[uint32][]Ref{}, from: map[uint32][]Ref{}}
+}
+
+// Build returns an Index of the references made by instructions, whose
+// operands must have been set. Only addresses held in the instructions
+// themselves are found: absolute operands, branch targets, and the vector read
+// by @@aa:8. Jumps and calls through registers or vectors are left to the
+// caller, who can Add what resolve.Resolve finds of them.
+func Build(instructions []instruction.Inst) *Index {
+	ix := New()
+	for n := range instructions {
+		ix.AddInst(&instructions[n])
+	}
+	return ix
+}
+
+// Add adds r to ix, unless it is already there.
+func (ix *Index) Add(r Ref) {
+	for _, o := range ix.from[r.From] {
+		if o == r {
+			return
+		}
+	}
+	ix.from[r.From] = append(ix.from[r.From], r)
+	ix.to[r.To] = append(ix.to[r.To], r)
+}
+
+// AddInst adds the references made by inst to ix.
+func (ix *Index) AddInst(inst *instruction.Inst) {
+	from := uint32(inst.Pos)
+	class := inst.Class()
+	for n, op := range inst.Operands() {
+		if p, ok := op.PCRelative(); ok {
+			if k, ok := transfer(class); ok {
+				ix.Add(Ref{From: from, To: uint32(p.Target) & 0xFFFFFF, Kind: k})
+			}
+		} else if a, ok := op.Absolute(); ok {
+			if k, ok := transfer(class); ok {
+				ix.Add(Ref{From: from, To: a.EffectiveAddress(), Kind: k})
+				continue
+			}
+			read, written := inst.Accesses(n)
+			if read {
+				ix.Add(Ref{From: from, To: a.EffectiveAddress(), Kind: Read})
+			}
+			if written {
+				ix.Add(Ref{From: from, To: a.EffectiveAddress(), Kind: Write})
+			}
+		} else if m, ok := op.MemoryIndirect(); ok {
+			// The vector is in the first 256 bytes of memory, unlike @aa:8.
+			ix.Add(Ref{From: from, To: uint32(m.Address), Kind: Read})
+		}
+	}
+}
+
+// transfer returns the kind of reference an instruction of class c makes to
+// its target, if it transfers control to one.
+func transfer(c instruction.Class) (Kind, bool) {
+	if c&instruction.Call != 0 {
+		return Call, true
+	}
+	if c&instruction.Branch != 0 {
+		return Jump, true
+	}
+	return 0, false
+}
+
+// To returns the references to addr, ordered by the address they come from.
+func (ix *Index) To(addr uint32) []Ref {
+	return sorted(ix.to[addr])
+}
+
+// From returns the references made by the instruction at addr.
+func (ix *Index) From(addr uint32) []Ref {
+	return sorted(ix.from[addr])
+}
+
+// Targets returns every address that is referred to, in ascending order.
+func (ix *Index) Targets() []uint32 {
+	addrs := make([]uint32, 0, len(ix.to))
+	for addr := range ix.to {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(a, b int) bool { return addrs[a] < addrs[b] })
+	return addrs
+}
+
+func sorted(refs []Ref) []Ref {
+	out := append([]Ref(nil), refs...)
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].From != out[b].From {
+			return out[a].From < out[b].From
+		}
+		if out[a].To != out[b].To {
+			return out[a].To < out[b].To
+		}
+		return out[a].Kind < out[b].Kind
+	})
+	return out
+}
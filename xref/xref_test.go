@@ -0,0 +1,54 @@
+package xref_test
+
+import (
+	"testing"
+
+	"github.com/kn100/cybemu/disassembler"
+	"github.com/kn100/cybemu/xref"
+	"github.com/stretchr/testify/assert"
+)
+
+var code = []byte{
+	0x5E, 0x00, 0x01, 0x00, // 000: jsr @0x000100:24
+	0x6A, 0x08, 0x12, 0x34, // 004: mov.b @0x1234:16, r0l
+	0x3A, 0x40, // 008: mov.b r2l, @0x40:8
+	0x40, 0xF4, // 00A: bra 0x000000:8
+	0x7F, 0x40, 0x70, 0x30, // 00C: bset #3, @0x40:8
+	0x5F, 0x20, // 010: jsr @@0x20:8
+	0x41, 0x00, // 012: brn
+	0x55, 0xEA, // 014: bsr 0x000000:8
+}
+
+func TestBuild(t *testing.T) {
+	ix := xref.Build(disassembler.Disassemble(code))
+
+	assert.Equal(t, []xref.Ref{
+		{From: 0x00A, To: 0x000, Kind: xref.Jump},
+		{From: 0x014, To: 0x000, Kind: xref.Call},
+	}, ix.To(0x000))
+	assert.Equal(t, []xref.Ref{{From: 0x000, To: 0x100, Kind: xref.Call}}, ix.To(0x100))
+	assert.Equal(t, []xref.Ref{{From: 0x004, To: 0x1234, Kind: xref.Read}}, ix.To(0x1234))
+	assert.Equal(t, []xref.Ref{
+		{From: 0x008, To: 0xFFFF40, Kind: xref.Write},
+		{From: 0x00C, To: 0xFFFF40, Kind: xref.Read},
+		{From: 0x00C, To: 0xFFFF40, Kind: xref.Write},
+	}, ix.To(0xFFFF40))
+	assert.Equal(t, []xref.Ref{{From: 0x010, To: 0x20, Kind: xref.Read}}, ix.To(0x20))
+	assert.Empty(t, ix.From(0x012))
+	assert.Equal(t, []uint32{0x000, 0x020, 0x100, 0x1234, 0xFFFF40}, ix.Targets())
+}
+
+func TestAdd(t *testing.T) {
+	ix := xref.New()
+	r := xref.Ref{From: 0x10, To: 0x200, Kind: xref.Call}
+	ix.Add(r)
+	ix.Add(r)
+	assert.Equal(t, []xref.Ref{r}, ix.To(0x200))
+	assert.Equal(t, []xref.Ref{r}, ix.From(0x10))
+}
+
+func TestKindString(t *testing.T) {
+	assert.Equal(t, "jump", xref.Jump.String())
+	assert.Equal(t, "write", xref.Write.String())
+	assert.Equal(t, "unknown", xref.Kind(9).String())
+}